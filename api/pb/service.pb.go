@@ -9,6 +9,7 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -68,6 +69,12 @@ func (x *WaitContainerRequest) GetContainerId() string {
 type WaitContainerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TimedOut      bool                   `protobuf:"varint,2,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	OomKilled     bool                   `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -109,13 +116,64 @@ func (x *WaitContainerResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *WaitContainerResponse) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *WaitContainerResponse) GetOomKilled() bool {
+	if x != nil {
+		return x.OomKilled
+	}
+	return false
+}
+
+func (x *WaitContainerResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *WaitContainerResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *WaitContainerResponse) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *WaitContainerResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
 type StartContainerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Image         string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
-	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Image          string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Command        string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Code           string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	TimeoutSeconds int32                  `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	Limits         *ResourceLimits        `protobuf:"bytes,5,opt,name=limits,proto3" json:"limits,omitempty"`
+	SandboxProfile string                 `protobuf:"bytes,6,opt,name=sandbox_profile,json=sandboxProfile,proto3" json:"sandbox_profile,omitempty"`
+	JobId          string                 `protobuf:"bytes,7,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Runtime        *Runtime               `protobuf:"bytes,8,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	Archive        []byte                 `protobuf:"bytes,9,opt,name=archive,proto3" json:"archive,omitempty"`
+	Entrypoint     string                 `protobuf:"bytes,10,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	Stdin          string                 `protobuf:"bytes,11,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	Tests          []*TestInput           `protobuf:"bytes,12,rep,name=tests,proto3" json:"tests,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *StartContainerRequest) Reset() {
@@ -169,27 +227,91 @@ func (x *StartContainerRequest) GetCode() string {
 	return ""
 }
 
-type StartContainerResponse struct {
+func (x *StartContainerRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *StartContainerRequest) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *StartContainerRequest) GetSandboxProfile() string {
+	if x != nil {
+		return x.SandboxProfile
+	}
+	return ""
+}
+
+func (x *StartContainerRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *StartContainerRequest) GetRuntime() *Runtime {
+	if x != nil {
+		return x.Runtime
+	}
+	return nil
+}
+
+func (x *StartContainerRequest) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *StartContainerRequest) GetEntrypoint() string {
+	if x != nil {
+		return x.Entrypoint
+	}
+	return ""
+}
+
+func (x *StartContainerRequest) GetStdin() string {
+	if x != nil {
+		return x.Stdin
+	}
+	return ""
+}
+
+func (x *StartContainerRequest) GetTests() []*TestInput {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+type TestInput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Input         string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	TimeLimitMs   int32                  `protobuf:"varint,2,opt,name=time_limit_ms,json=timeLimitMs,proto3" json:"time_limit_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartContainerResponse) Reset() {
-	*x = StartContainerResponse{}
+func (x *TestInput) Reset() {
+	*x = TestInput{}
 	mi := &file_api_proto_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartContainerResponse) String() string {
+func (x *TestInput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartContainerResponse) ProtoMessage() {}
+func (*TestInput) ProtoMessage() {}
 
-func (x *StartContainerResponse) ProtoReflect() protoreflect.Message {
+func (x *TestInput) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -201,39 +323,51 @@ func (x *StartContainerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartContainerResponse.ProtoReflect.Descriptor instead.
-func (*StartContainerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TestInput.ProtoReflect.Descriptor instead.
+func (*TestInput) Descriptor() ([]byte, []int) {
 	return file_api_proto_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *StartContainerResponse) GetContainerId() string {
+func (x *TestInput) GetInput() string {
 	if x != nil {
-		return x.ContainerId
+		return x.Input
 	}
 	return ""
 }
 
-type StopContainerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TestInput) GetTimeLimitMs() int32 {
+	if x != nil {
+		return x.TimeLimitMs
+	}
+	return 0
 }
 
-func (x *StopContainerRequest) Reset() {
-	*x = StopContainerRequest{}
+type Runtime struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Language              string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Version               string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	FileName              string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	RunCommand            string                 `protobuf:"bytes,4,opt,name=run_command,json=runCommand,proto3" json:"run_command,omitempty"`
+	CompileCommand        string                 `protobuf:"bytes,5,opt,name=compile_command,json=compileCommand,proto3" json:"compile_command,omitempty"`
+	CompileTimeoutSeconds int32                  `protobuf:"varint,6,opt,name=compile_timeout_seconds,json=compileTimeoutSeconds,proto3" json:"compile_timeout_seconds,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Runtime) Reset() {
+	*x = Runtime{}
 	mi := &file_api_proto_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopContainerRequest) String() string {
+func (x *Runtime) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopContainerRequest) ProtoMessage() {}
+func (*Runtime) ProtoMessage() {}
 
-func (x *StopContainerRequest) ProtoReflect() protoreflect.Message {
+func (x *Runtime) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,39 +379,77 @@ func (x *StopContainerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopContainerRequest.ProtoReflect.Descriptor instead.
-func (*StopContainerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Runtime.ProtoReflect.Descriptor instead.
+func (*Runtime) Descriptor() ([]byte, []int) {
 	return file_api_proto_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *StopContainerRequest) GetContainerId() string {
+func (x *Runtime) GetLanguage() string {
 	if x != nil {
-		return x.ContainerId
+		return x.Language
 	}
 	return ""
 }
 
-type StopContainerResponse struct {
+func (x *Runtime) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Runtime) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Runtime) GetRunCommand() string {
+	if x != nil {
+		return x.RunCommand
+	}
+	return ""
+}
+
+func (x *Runtime) GetCompileCommand() string {
+	if x != nil {
+		return x.CompileCommand
+	}
+	return ""
+}
+
+func (x *Runtime) GetCompileTimeoutSeconds() int32 {
+	if x != nil {
+		return x.CompileTimeoutSeconds
+	}
+	return 0
+}
+
+type ResourceLimits struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	MemoryMb      int64                  `protobuf:"varint,1,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	Cpus          float64                `protobuf:"fixed64,2,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	PidsLimit     int64                  `protobuf:"varint,3,opt,name=pids_limit,json=pidsLimit,proto3" json:"pids_limit,omitempty"`
+	TmpfsMb       int64                  `protobuf:"varint,4,opt,name=tmpfs_mb,json=tmpfsMb,proto3" json:"tmpfs_mb,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopContainerResponse) Reset() {
-	*x = StopContainerResponse{}
+func (x *ResourceLimits) Reset() {
+	*x = ResourceLimits{}
 	mi := &file_api_proto_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopContainerResponse) String() string {
+func (x *ResourceLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopContainerResponse) ProtoMessage() {}
+func (*ResourceLimits) ProtoMessage() {}
 
-func (x *StopContainerResponse) ProtoReflect() protoreflect.Message {
+func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -289,39 +461,62 @@ func (x *StopContainerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopContainerResponse.ProtoReflect.Descriptor instead.
-func (*StopContainerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
 	return file_api_proto_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *StopContainerResponse) GetSuccess() bool {
+func (x *ResourceLimits) GetMemoryMb() int64 {
 	if x != nil {
-		return x.Success
+		return x.MemoryMb
 	}
-	return false
+	return 0
 }
 
-type GetLogsRequest struct {
+func (x *ResourceLimits) GetCpus() float64 {
+	if x != nil {
+		return x.Cpus
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetPidsLimit() int64 {
+	if x != nil {
+		return x.PidsLimit
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetTmpfsMb() int64 {
+	if x != nil {
+		return x.TmpfsMb
+	}
+	return 0
+}
+
+type StartContainerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Compile       *CompileResult         `protobuf:"bytes,2,opt,name=compile,proto3" json:"compile,omitempty"`
+	Tests         []*TestOutcome         `protobuf:"bytes,3,rep,name=tests,proto3" json:"tests,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLogsRequest) Reset() {
-	*x = GetLogsRequest{}
+func (x *StartContainerResponse) Reset() {
+	*x = StartContainerResponse{}
 	mi := &file_api_proto_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLogsRequest) String() string {
+func (x *StartContainerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLogsRequest) ProtoMessage() {}
+func (*StartContainerResponse) ProtoMessage() {}
 
-func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
+func (x *StartContainerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -333,39 +528,57 @@ func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLogsRequest.ProtoReflect.Descriptor instead.
-func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartContainerResponse.ProtoReflect.Descriptor instead.
+func (*StartContainerResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetLogsRequest) GetContainerId() string {
+func (x *StartContainerResponse) GetContainerId() string {
 	if x != nil {
 		return x.ContainerId
 	}
 	return ""
 }
 
-type GetLogsResponse struct {
+func (x *StartContainerResponse) GetCompile() *CompileResult {
+	if x != nil {
+		return x.Compile
+	}
+	return nil
+}
+
+func (x *StartContainerResponse) GetTests() []*TestOutcome {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+type CompileResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Logs          string                 `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Output        string                 `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	TimedOut      bool                   `protobuf:"varint,5,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLogsResponse) Reset() {
-	*x = GetLogsResponse{}
+func (x *CompileResult) Reset() {
+	*x = CompileResult{}
 	mi := &file_api_proto_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLogsResponse) String() string {
+func (x *CompileResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLogsResponse) ProtoMessage() {}
+func (*CompileResult) ProtoMessage() {}
 
-func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
+func (x *CompileResult) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -377,46 +590,1674 @@ func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLogsResponse.ProtoReflect.Descriptor instead.
-func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompileResult.ProtoReflect.Descriptor instead.
+func (*CompileResult) Descriptor() ([]byte, []int) {
 	return file_api_proto_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetLogsResponse) GetLogs() string {
+func (x *CompileResult) GetSuccess() bool {
 	if x != nil {
-		return x.Logs
+		return x.Success
+	}
+	return false
+}
+
+func (x *CompileResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *CompileResult) GetOutput() string {
+	if x != nil {
+		return x.Output
 	}
 	return ""
 }
 
-var File_api_proto_service_proto protoreflect.FileDescriptor
+func (x *CompileResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
 
-const file_api_proto_service_proto_rawDesc = "" +
-	"\n" +
-	"\x17api/proto/service.proto\x12\x02pb\"9\n" +
-	"\x14WaitContainerRequest\x12!\n" +
-	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"1\n" +
-	"\x15WaitContainerResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"[\n" +
-	"\x15StartContainerRequest\x12\x14\n" +
-	"\x05image\x18\x01 \x01(\tR\x05image\x12\x18\n" +
-	"\acommand\x18\x02 \x01(\tR\acommand\x12\x12\n" +
-	"\x04code\x18\x03 \x01(\tR\x04code\";\n" +
-	"\x16StartContainerResponse\x12!\n" +
-	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"9\n" +
-	"\x14StopContainerRequest\x12!\n" +
-	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"1\n" +
-	"\x15StopContainerResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"3\n" +
-	"\x0eGetLogsRequest\x12!\n" +
-	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"%\n" +
-	"\x0fGetLogsResponse\x12\x12\n" +
-	"\x04logs\x18\x01 \x01(\tR\x04logs2\x98\x02\n" +
-	"\rWorkerService\x12G\n" +
-	"\x0eStartContainer\x12\x19.pb.StartContainerRequest\x1a\x1a.pb.StartContainerResponse\x12D\n" +
-	"\rStopContainer\x12\x18.pb.StopContainerRequest\x1a\x19.pb.StopContainerResponse\x12D\n" +
-	"\rWaitContainer\x12\x18.pb.WaitContainerRequest\x1a\x19.pb.WaitContainerResponse\x122\n" +
-	"\aGetLogs\x12\x12.pb.GetLogsRequest\x1a\x13.pb.GetLogsResponseB\"Z github.com/JullMol/nebula/api/pbb\x06proto3"
+func (x *CompileResult) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+type StopContainerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopContainerRequest) Reset() {
+	*x = StopContainerRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopContainerRequest) ProtoMessage() {}
+
+func (x *StopContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopContainerRequest.ProtoReflect.Descriptor instead.
+func (*StopContainerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StopContainerRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type StopContainerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopContainerResponse) Reset() {
+	*x = StopContainerResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopContainerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopContainerResponse) ProtoMessage() {}
+
+func (x *StopContainerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopContainerResponse.ProtoReflect.Descriptor instead.
+func (*StopContainerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StopContainerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RemoveContainerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveContainerRequest) Reset() {
+	*x = RemoveContainerRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveContainerRequest) ProtoMessage() {}
+
+func (x *RemoveContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveContainerRequest.ProtoReflect.Descriptor instead.
+func (*RemoveContainerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveContainerRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *RemoveContainerRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type RemoveContainerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveContainerResponse) Reset() {
+	*x = RemoveContainerResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveContainerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveContainerResponse) ProtoMessage() {}
+
+func (x *RemoveContainerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveContainerResponse.ProtoReflect.Descriptor instead.
+func (*RemoveContainerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveContainerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListContainersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersRequest) Reset() {
+	*x = ListContainersRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersRequest) ProtoMessage() {}
+
+func (x *ListContainersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersRequest.ProtoReflect.Descriptor instead.
+func (*ListContainersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{12}
+}
+
+type ContainerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerInfo) Reset() {
+	*x = ContainerInfo{}
+	mi := &file_api_proto_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerInfo) ProtoMessage() {}
+
+func (x *ContainerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerInfo.ProtoReflect.Descriptor instead.
+func (*ContainerInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ContainerInfo) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListContainersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Containers    []*ContainerInfo       `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersResponse) Reset() {
+	*x = ListContainersResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersResponse) ProtoMessage() {}
+
+func (x *ListContainersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersResponse.ProtoReflect.Descriptor instead.
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListContainersResponse) GetContainers() []*ContainerInfo {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type GetLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogsRequest) Reset() {
+	*x = GetLogsRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsRequest) ProtoMessage() {}
+
+func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetLogsRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type GetLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          string                 `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	Stdout        string                 `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        string                 `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogsResponse) Reset() {
+	*x = GetLogsResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsResponse) ProtoMessage() {}
+
+func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetLogsResponse) GetLogs() string {
+	if x != nil {
+		return x.Logs
+	}
+	return ""
+}
+
+func (x *GetLogsResponse) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *GetLogsResponse) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+type TestOutcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Stdout        string                 `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        string                 `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	TimeMs        int64                  `protobuf:"varint,5,opt,name=time_ms,json=timeMs,proto3" json:"time_ms,omitempty"`
+	MemoryKb      int64                  `protobuf:"varint,6,opt,name=memory_kb,json=memoryKb,proto3" json:"memory_kb,omitempty"`
+	TimedOut      bool                   `protobuf:"varint,7,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	OomKilled     bool                   `protobuf:"varint,8,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestOutcome) Reset() {
+	*x = TestOutcome{}
+	mi := &file_api_proto_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestOutcome) ProtoMessage() {}
+
+func (x *TestOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestOutcome.ProtoReflect.Descriptor instead.
+func (*TestOutcome) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TestOutcome) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TestOutcome) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *TestOutcome) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *TestOutcome) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *TestOutcome) GetTimeMs() int64 {
+	if x != nil {
+		return x.TimeMs
+	}
+	return 0
+}
+
+func (x *TestOutcome) GetMemoryKb() int64 {
+	if x != nil {
+		return x.MemoryKb
+	}
+	return 0
+}
+
+func (x *TestOutcome) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *TestOutcome) GetOomKilled() bool {
+	if x != nil {
+		return x.OomKilled
+	}
+	return false
+}
+
+type StreamLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamLogsRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type LogChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stream        string                 `protobuf:"bytes,1,opt,name=stream,proto3" json:"stream,omitempty"`
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogChunk) Reset() {
+	*x = LogChunk{}
+	mi := &file_api_proto_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogChunk) ProtoMessage() {}
+
+func (x *LogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogChunk.ProtoReflect.Descriptor instead.
+func (*LogChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LogChunk) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *LogChunk) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type RegisterWorkerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Capacity      int32                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Weight        int32                  `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	TotalMemoryMb int64                  `protobuf:"varint,6,opt,name=total_memory_mb,json=totalMemoryMb,proto3" json:"total_memory_mb,omitempty"`
+	TotalCpus     float64                `protobuf:"fixed64,7,opt,name=total_cpus,json=totalCpus,proto3" json:"total_cpus,omitempty"`
+	DefaultLimits *ResourceLimits        `protobuf:"bytes,8,opt,name=default_limits,json=defaultLimits,proto3" json:"default_limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWorkerRequest) Reset() {
+	*x = RegisterWorkerRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkerRequest) ProtoMessage() {}
+
+func (x *RegisterWorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkerRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWorkerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RegisterWorkerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterWorkerRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterWorkerRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *RegisterWorkerRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RegisterWorkerRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RegisterWorkerRequest) GetTotalMemoryMb() int64 {
+	if x != nil {
+		return x.TotalMemoryMb
+	}
+	return 0
+}
+
+func (x *RegisterWorkerRequest) GetTotalCpus() float64 {
+	if x != nil {
+		return x.TotalCpus
+	}
+	return 0
+}
+
+func (x *RegisterWorkerRequest) GetDefaultLimits() *ResourceLimits {
+	if x != nil {
+		return x.DefaultLimits
+	}
+	return nil
+}
+
+type RegisterWorkerResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	HeartbeatIntervalSeconds int32                  `protobuf:"varint,1,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *RegisterWorkerResponse) Reset() {
+	*x = RegisterWorkerResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWorkerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkerResponse) ProtoMessage() {}
+
+func (x *RegisterWorkerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkerResponse.ProtoReflect.Descriptor instead.
+func (*RegisterWorkerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RegisterWorkerResponse) GetHeartbeatIntervalSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+type HeartbeatRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Address           string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	RunningContainers int32                  `protobuf:"varint,2,opt,name=running_containers,json=runningContainers,proto3" json:"running_containers,omitempty"`
+	FreeMemoryMb      int64                  `protobuf:"varint,3,opt,name=free_memory_mb,json=freeMemoryMb,proto3" json:"free_memory_mb,omitempty"`
+	FreeCpus          float64                `protobuf:"fixed64,4,opt,name=free_cpus,json=freeCpus,proto3" json:"free_cpus,omitempty"`
+	Images            []string               `protobuf:"bytes,5,rep,name=images,proto3" json:"images,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HeartbeatRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetRunningContainers() int32 {
+	if x != nil {
+		return x.RunningContainers
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetFreeMemoryMb() int64 {
+	if x != nil {
+		return x.FreeMemoryMb
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetFreeCpus() float64 {
+	if x != nil {
+		return x.FreeCpus
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Known         bool                   `protobuf:"varint,1,opt,name=known,proto3" json:"known,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HeartbeatResponse) GetKnown() bool {
+	if x != nil {
+		return x.Known
+	}
+	return false
+}
+
+type ListWorkersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkersRequest) Reset() {
+	*x = ListWorkersRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkersRequest) ProtoMessage() {}
+
+func (x *ListWorkersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{24}
+}
+
+type WorkerHealth struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	State               string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	ConsecutiveFailures int32                  `protobuf:"varint,2,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	OpenedAt            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	LastError           string                 `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WorkerHealth) Reset() {
+	*x = WorkerHealth{}
+	mi := &file_api_proto_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerHealth) ProtoMessage() {}
+
+func (x *WorkerHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerHealth.ProtoReflect.Descriptor instead.
+func (*WorkerHealth) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WorkerHealth) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *WorkerHealth) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *WorkerHealth) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *WorkerHealth) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type WorkerStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Capacity      int32                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Running       int32                  `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	Static        bool                   `protobuf:"varint,6,opt,name=static,proto3" json:"static,omitempty"`
+	Weight        int32                  `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`
+	TotalMemoryMb int64                  `protobuf:"varint,8,opt,name=total_memory_mb,json=totalMemoryMb,proto3" json:"total_memory_mb,omitempty"`
+	TotalCpus     float64                `protobuf:"fixed64,9,opt,name=total_cpus,json=totalCpus,proto3" json:"total_cpus,omitempty"`
+	FreeMemoryMb  int64                  `protobuf:"varint,10,opt,name=free_memory_mb,json=freeMemoryMb,proto3" json:"free_memory_mb,omitempty"`
+	FreeCpus      float64                `protobuf:"fixed64,11,opt,name=free_cpus,json=freeCpus,proto3" json:"free_cpus,omitempty"`
+	Images        []string               `protobuf:"bytes,12,rep,name=images,proto3" json:"images,omitempty"`
+	RegisteredAt  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	LastHeartbeat *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	Health        *WorkerHealth          `protobuf:"bytes,15,opt,name=health,proto3" json:"health,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerStatus) Reset() {
+	*x = WorkerStatus{}
+	mi := &file_api_proto_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStatus) ProtoMessage() {}
+
+func (x *WorkerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStatus.ProtoReflect.Descriptor instead.
+func (*WorkerStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WorkerStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *WorkerStatus) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WorkerStatus) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetStatic() bool {
+	if x != nil {
+		return x.Static
+	}
+	return false
+}
+
+func (x *WorkerStatus) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetTotalMemoryMb() int64 {
+	if x != nil {
+		return x.TotalMemoryMb
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetTotalCpus() float64 {
+	if x != nil {
+		return x.TotalCpus
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetFreeMemoryMb() int64 {
+	if x != nil {
+		return x.FreeMemoryMb
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetFreeCpus() float64 {
+	if x != nil {
+		return x.FreeCpus
+	}
+	return 0
+}
+
+func (x *WorkerStatus) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *WorkerStatus) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return nil
+}
+
+func (x *WorkerStatus) GetLastHeartbeat() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeat
+	}
+	return nil
+}
+
+func (x *WorkerStatus) GetHealth() *WorkerHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+type ListWorkersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workers       []*WorkerStatus        `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkersResponse) Reset() {
+	*x = ListWorkersResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkersResponse) ProtoMessage() {}
+
+func (x *ListWorkersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListWorkersResponse) GetWorkers() []*WorkerStatus {
+	if x != nil {
+		return x.Workers
+	}
+	return nil
+}
+
+type CheckPlacementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeSelector  map[string]string      `protobuf:"bytes,1,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AntiAffinity  map[string]string      `protobuf:"bytes,2,rep,name=anti_affinity,json=antiAffinity,proto3" json:"anti_affinity,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPlacementRequest) Reset() {
+	*x = CheckPlacementRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPlacementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPlacementRequest) ProtoMessage() {}
+
+func (x *CheckPlacementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPlacementRequest.ProtoReflect.Descriptor instead.
+func (*CheckPlacementRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CheckPlacementRequest) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *CheckPlacementRequest) GetAntiAffinity() map[string]string {
+	if x != nil {
+		return x.AntiAffinity
+	}
+	return nil
+}
+
+type CheckPlacementResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Placeable       bool                   `protobuf:"varint,1,opt,name=placeable,proto3" json:"placeable,omitempty"`
+	MatchingWorkers int32                  `protobuf:"varint,2,opt,name=matching_workers,json=matchingWorkers,proto3" json:"matching_workers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckPlacementResponse) Reset() {
+	*x = CheckPlacementResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPlacementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPlacementResponse) ProtoMessage() {}
+
+func (x *CheckPlacementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPlacementResponse.ProtoReflect.Descriptor instead.
+func (*CheckPlacementResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CheckPlacementResponse) GetPlaceable() bool {
+	if x != nil {
+		return x.Placeable
+	}
+	return false
+}
+
+func (x *CheckPlacementResponse) GetMatchingWorkers() int32 {
+	if x != nil {
+		return x.MatchingWorkers
+	}
+	return 0
+}
+
+type StopJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopJobRequest) Reset() {
+	*x = StopJobRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobRequest) ProtoMessage() {}
+
+func (x *StopJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobRequest.ProtoReflect.Descriptor instead.
+func (*StopJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StopJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type StopJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stopped       bool                   `protobuf:"varint,1,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopJobResponse) Reset() {
+	*x = StopJobResponse{}
+	mi := &file_api_proto_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobResponse) ProtoMessage() {}
+
+func (x *StopJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobResponse.ProtoReflect.Descriptor instead.
+func (*StopJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StopJobResponse) GetStopped() bool {
+	if x != nil {
+		return x.Stopped
+	}
+	return false
+}
+
+type StreamJobLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJobLogsRequest) Reset() {
+	*x = StreamJobLogsRequest{}
+	mi := &file_api_proto_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJobLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJobLogsRequest) ProtoMessage() {}
+
+func (x *StreamJobLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJobLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamJobLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *StreamJobLogsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+var File_api_proto_service_proto protoreflect.FileDescriptor
+
+const file_api_proto_service_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/proto/service.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"9\n" +
+	"\x14WaitContainerRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"\xa3\x02\n" +
+	"\x15WaitContainerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\ttimed_out\x18\x02 \x01(\bR\btimedOut\x12\x1d\n" +
+	"\n" +
+	"oom_killed\x18\x03 \x01(\bR\toomKilled\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\"\x8c\x03\n" +
+	"\x15StartContainerRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\tR\x05image\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\x12*\n" +
+	"\x06limits\x18\x05 \x01(\v2\x12.pb.ResourceLimitsR\x06limits\x12'\n" +
+	"\x0fsandbox_profile\x18\x06 \x01(\tR\x0esandboxProfile\x12\x15\n" +
+	"\x06job_id\x18\a \x01(\tR\x05jobId\x12%\n" +
+	"\aruntime\x18\b \x01(\v2\v.pb.RuntimeR\aruntime\x12\x18\n" +
+	"\aarchive\x18\t \x01(\fR\aarchive\x12\x1e\n" +
+	"\n" +
+	"entrypoint\x18\n" +
+	" \x01(\tR\n" +
+	"entrypoint\x12\x14\n" +
+	"\x05stdin\x18\v \x01(\tR\x05stdin\x12#\n" +
+	"\x05tests\x18\f \x03(\v2\r.pb.TestInputR\x05tests\"E\n" +
+	"\tTestInput\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\"\n" +
+	"\rtime_limit_ms\x18\x02 \x01(\x05R\vtimeLimitMs\"\xde\x01\n" +
+	"\aRuntime\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1f\n" +
+	"\vrun_command\x18\x04 \x01(\tR\n" +
+	"runCommand\x12'\n" +
+	"\x0fcompile_command\x18\x05 \x01(\tR\x0ecompileCommand\x126\n" +
+	"\x17compile_timeout_seconds\x18\x06 \x01(\x05R\x15compileTimeoutSeconds\"{\n" +
+	"\x0eResourceLimits\x12\x1b\n" +
+	"\tmemory_mb\x18\x01 \x01(\x03R\bmemoryMb\x12\x12\n" +
+	"\x04cpus\x18\x02 \x01(\x01R\x04cpus\x12\x1d\n" +
+	"\n" +
+	"pids_limit\x18\x03 \x01(\x03R\tpidsLimit\x12\x19\n" +
+	"\btmpfs_mb\x18\x04 \x01(\x03R\atmpfsMb\"\x8f\x01\n" +
+	"\x16StartContainerResponse\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12+\n" +
+	"\acompile\x18\x02 \x01(\v2\x11.pb.CompileResultR\acompile\x12%\n" +
+	"\x05tests\x18\x03 \x03(\v2\x0f.pb.TestOutcomeR\x05tests\"\x9c\x01\n" +
+	"\rCompileResult\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06output\x18\x03 \x01(\tR\x06output\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12\x1b\n" +
+	"\ttimed_out\x18\x05 \x01(\bR\btimedOut\"9\n" +
+	"\x14StopContainerRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"1\n" +
+	"\x15StopContainerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Q\n" +
+	"\x16RemoveContainerRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"3\n" +
+	"\x17RemoveContainerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x17\n" +
+	"\x15ListContainersRequest\"\x9a\x01\n" +
+	"\rContainerInfo\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"K\n" +
+	"\x16ListContainersResponse\x121\n" +
+	"\n" +
+	"containers\x18\x01 \x03(\v2\x11.pb.ContainerInfoR\n" +
+	"containers\"3\n" +
+	"\x0eGetLogsRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"U\n" +
+	"\x0fGetLogsResponse\x12\x12\n" +
+	"\x04logs\x18\x01 \x01(\tR\x04logs\x12\x16\n" +
+	"\x06stdout\x18\x02 \x01(\tR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x03 \x01(\tR\x06stderr\"\xe2\x01\n" +
+	"\vTestOutcome\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06stdout\x18\x02 \x01(\tR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x03 \x01(\tR\x06stderr\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x17\n" +
+	"\atime_ms\x18\x05 \x01(\x03R\x06timeMs\x12\x1b\n" +
+	"\tmemory_kb\x18\x06 \x01(\x03R\bmemoryKb\x12\x1b\n" +
+	"\ttimed_out\x18\a \x01(\bR\btimedOut\x12\x1d\n" +
+	"\n" +
+	"oom_killed\x18\b \x01(\bR\toomKilled\"6\n" +
+	"\x11StreamLogsRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\"6\n" +
+	"\bLogChunk\x12\x16\n" +
+	"\x06stream\x18\x01 \x01(\tR\x06stream\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\"\xf5\x02\n" +
+	"\x15RegisterWorkerRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\x12=\n" +
+	"\x06labels\x18\x04 \x03(\v2%.pb.RegisterWorkerRequest.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06weight\x18\x05 \x01(\x05R\x06weight\x12&\n" +
+	"\x0ftotal_memory_mb\x18\x06 \x01(\x03R\rtotalMemoryMb\x12\x1d\n" +
+	"\n" +
+	"total_cpus\x18\a \x01(\x01R\ttotalCpus\x129\n" +
+	"\x0edefault_limits\x18\b \x01(\v2\x12.pb.ResourceLimitsR\rdefaultLimits\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"V\n" +
+	"\x16RegisterWorkerResponse\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x01 \x01(\x05R\x18heartbeatIntervalSeconds\"\xb6\x01\n" +
+	"\x10HeartbeatRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12-\n" +
+	"\x12running_containers\x18\x02 \x01(\x05R\x11runningContainers\x12$\n" +
+	"\x0efree_memory_mb\x18\x03 \x01(\x03R\ffreeMemoryMb\x12\x1b\n" +
+	"\tfree_cpus\x18\x04 \x01(\x01R\bfreeCpus\x12\x16\n" +
+	"\x06images\x18\x05 \x03(\tR\x06images\")\n" +
+	"\x11HeartbeatResponse\x12\x14\n" +
+	"\x05known\x18\x01 \x01(\bR\x05known\"\x14\n" +
+	"\x12ListWorkersRequest\"\xaf\x01\n" +
+	"\fWorkerHealth\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x121\n" +
+	"\x14consecutive_failures\x18\x02 \x01(\x05R\x13consecutiveFailures\x127\n" +
+	"\topened_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tR\tlastError\"\xe3\x04\n" +
+	"\fWorkerStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\x124\n" +
+	"\x06labels\x18\x04 \x03(\v2\x1c.pb.WorkerStatus.LabelsEntryR\x06labels\x12\x18\n" +
+	"\arunning\x18\x05 \x01(\x05R\arunning\x12\x16\n" +
+	"\x06static\x18\x06 \x01(\bR\x06static\x12\x16\n" +
+	"\x06weight\x18\a \x01(\x05R\x06weight\x12&\n" +
+	"\x0ftotal_memory_mb\x18\b \x01(\x03R\rtotalMemoryMb\x12\x1d\n" +
+	"\n" +
+	"total_cpus\x18\t \x01(\x01R\ttotalCpus\x12$\n" +
+	"\x0efree_memory_mb\x18\n" +
+	" \x01(\x03R\ffreeMemoryMb\x12\x1b\n" +
+	"\tfree_cpus\x18\v \x01(\x01R\bfreeCpus\x12\x16\n" +
+	"\x06images\x18\f \x03(\tR\x06images\x12?\n" +
+	"\rregistered_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x12A\n" +
+	"\x0elast_heartbeat\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\rlastHeartbeat\x12(\n" +
+	"\x06health\x18\x0f \x01(\v2\x10.pb.WorkerHealthR\x06health\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\x13ListWorkersResponse\x12*\n" +
+	"\aworkers\x18\x01 \x03(\v2\x10.pb.WorkerStatusR\aworkers\"\xbd\x02\n" +
+	"\x15CheckPlacementRequest\x12P\n" +
+	"\rnode_selector\x18\x01 \x03(\v2+.pb.CheckPlacementRequest.NodeSelectorEntryR\fnodeSelector\x12P\n" +
+	"\ranti_affinity\x18\x02 \x03(\v2+.pb.CheckPlacementRequest.AntiAffinityEntryR\fantiAffinity\x1a?\n" +
+	"\x11NodeSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
+	"\x11AntiAffinityEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x16CheckPlacementResponse\x12\x1c\n" +
+	"\tplaceable\x18\x01 \x01(\bR\tplaceable\x12)\n" +
+	"\x10matching_workers\x18\x02 \x01(\x05R\x0fmatchingWorkers\"'\n" +
+	"\x0eStopJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"+\n" +
+	"\x0fStopJobResponse\x12\x18\n" +
+	"\astopped\x18\x01 \x01(\bR\astopped\"-\n" +
+	"\x14StreamJobLogsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId2\xe2\x03\n" +
+	"\rWorkerService\x12G\n" +
+	"\x0eStartContainer\x12\x19.pb.StartContainerRequest\x1a\x1a.pb.StartContainerResponse\x12D\n" +
+	"\rStopContainer\x12\x18.pb.StopContainerRequest\x1a\x19.pb.StopContainerResponse\x12D\n" +
+	"\rWaitContainer\x12\x18.pb.WaitContainerRequest\x1a\x19.pb.WaitContainerResponse\x122\n" +
+	"\aGetLogs\x12\x12.pb.GetLogsRequest\x1a\x13.pb.GetLogsResponse\x123\n" +
+	"\n" +
+	"StreamLogs\x12\x15.pb.StreamLogsRequest\x1a\f.pb.LogChunk0\x01\x12G\n" +
+	"\x0eListContainers\x12\x19.pb.ListContainersRequest\x1a\x1a.pb.ListContainersResponse\x12J\n" +
+	"\x0fRemoveContainer\x12\x1a.pb.RemoveContainerRequest\x1a\x1b.pb.RemoveContainerResponse2\x91\x01\n" +
+	"\fControlPlane\x12G\n" +
+	"\x0eRegisterWorker\x12\x19.pb.RegisterWorkerRequest\x1a\x1a.pb.RegisterWorkerResponse\x128\n" +
+	"\tHeartbeat\x12\x14.pb.HeartbeatRequest\x1a\x15.pb.HeartbeatResponse2\x86\x02\n" +
+	"\fOrchestrator\x12>\n" +
+	"\vListWorkers\x12\x16.pb.ListWorkersRequest\x1a\x17.pb.ListWorkersResponse\x12G\n" +
+	"\x0eCheckPlacement\x12\x19.pb.CheckPlacementRequest\x1a\x1a.pb.CheckPlacementResponse\x122\n" +
+	"\aStopJob\x12\x12.pb.StopJobRequest\x1a\x13.pb.StopJobResponse\x129\n" +
+	"\rStreamJobLogs\x12\x18.pb.StreamJobLogsRequest\x1a\f.pb.LogChunk0\x01B\"Z github.com/JullMol/nebula/api/pbb\x06proto3"
 
 var (
 	file_api_proto_service_proto_rawDescOnce sync.Once
@@ -430,31 +2271,98 @@ func file_api_proto_service_proto_rawDescGZIP() []byte {
 	return file_api_proto_service_proto_rawDescData
 }
 
-var file_api_proto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_api_proto_service_proto_goTypes = []any{
-	(*WaitContainerRequest)(nil),   // 0: pb.WaitContainerRequest
-	(*WaitContainerResponse)(nil),  // 1: pb.WaitContainerResponse
-	(*StartContainerRequest)(nil),  // 2: pb.StartContainerRequest
-	(*StartContainerResponse)(nil), // 3: pb.StartContainerResponse
-	(*StopContainerRequest)(nil),   // 4: pb.StopContainerRequest
-	(*StopContainerResponse)(nil),  // 5: pb.StopContainerResponse
-	(*GetLogsRequest)(nil),         // 6: pb.GetLogsRequest
-	(*GetLogsResponse)(nil),        // 7: pb.GetLogsResponse
+	(*WaitContainerRequest)(nil),    // 0: pb.WaitContainerRequest
+	(*WaitContainerResponse)(nil),   // 1: pb.WaitContainerResponse
+	(*StartContainerRequest)(nil),   // 2: pb.StartContainerRequest
+	(*TestInput)(nil),               // 3: pb.TestInput
+	(*Runtime)(nil),                 // 4: pb.Runtime
+	(*ResourceLimits)(nil),          // 5: pb.ResourceLimits
+	(*StartContainerResponse)(nil),  // 6: pb.StartContainerResponse
+	(*CompileResult)(nil),           // 7: pb.CompileResult
+	(*StopContainerRequest)(nil),    // 8: pb.StopContainerRequest
+	(*StopContainerResponse)(nil),   // 9: pb.StopContainerResponse
+	(*RemoveContainerRequest)(nil),  // 10: pb.RemoveContainerRequest
+	(*RemoveContainerResponse)(nil), // 11: pb.RemoveContainerResponse
+	(*ListContainersRequest)(nil),   // 12: pb.ListContainersRequest
+	(*ContainerInfo)(nil),           // 13: pb.ContainerInfo
+	(*ListContainersResponse)(nil),  // 14: pb.ListContainersResponse
+	(*GetLogsRequest)(nil),          // 15: pb.GetLogsRequest
+	(*GetLogsResponse)(nil),         // 16: pb.GetLogsResponse
+	(*TestOutcome)(nil),             // 17: pb.TestOutcome
+	(*StreamLogsRequest)(nil),       // 18: pb.StreamLogsRequest
+	(*LogChunk)(nil),                // 19: pb.LogChunk
+	(*RegisterWorkerRequest)(nil),   // 20: pb.RegisterWorkerRequest
+	(*RegisterWorkerResponse)(nil),  // 21: pb.RegisterWorkerResponse
+	(*HeartbeatRequest)(nil),        // 22: pb.HeartbeatRequest
+	(*HeartbeatResponse)(nil),       // 23: pb.HeartbeatResponse
+	(*ListWorkersRequest)(nil),      // 24: pb.ListWorkersRequest
+	(*WorkerHealth)(nil),            // 25: pb.WorkerHealth
+	(*WorkerStatus)(nil),            // 26: pb.WorkerStatus
+	(*ListWorkersResponse)(nil),     // 27: pb.ListWorkersResponse
+	(*CheckPlacementRequest)(nil),   // 28: pb.CheckPlacementRequest
+	(*CheckPlacementResponse)(nil),  // 29: pb.CheckPlacementResponse
+	(*StopJobRequest)(nil),          // 30: pb.StopJobRequest
+	(*StopJobResponse)(nil),         // 31: pb.StopJobResponse
+	(*StreamJobLogsRequest)(nil),    // 32: pb.StreamJobLogsRequest
+	nil,                             // 33: pb.RegisterWorkerRequest.LabelsEntry
+	nil,                             // 34: pb.WorkerStatus.LabelsEntry
+	nil,                             // 35: pb.CheckPlacementRequest.NodeSelectorEntry
+	nil,                             // 36: pb.CheckPlacementRequest.AntiAffinityEntry
+	(*timestamppb.Timestamp)(nil),   // 37: google.protobuf.Timestamp
 }
 var file_api_proto_service_proto_depIdxs = []int32{
-	2, // 0: pb.WorkerService.StartContainer:input_type -> pb.StartContainerRequest
-	4, // 1: pb.WorkerService.StopContainer:input_type -> pb.StopContainerRequest
-	0, // 2: pb.WorkerService.WaitContainer:input_type -> pb.WaitContainerRequest
-	6, // 3: pb.WorkerService.GetLogs:input_type -> pb.GetLogsRequest
-	3, // 4: pb.WorkerService.StartContainer:output_type -> pb.StartContainerResponse
-	5, // 5: pb.WorkerService.StopContainer:output_type -> pb.StopContainerResponse
-	1, // 6: pb.WorkerService.WaitContainer:output_type -> pb.WaitContainerResponse
-	7, // 7: pb.WorkerService.GetLogs:output_type -> pb.GetLogsResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	37, // 0: pb.WaitContainerResponse.started_at:type_name -> google.protobuf.Timestamp
+	37, // 1: pb.WaitContainerResponse.finished_at:type_name -> google.protobuf.Timestamp
+	5,  // 2: pb.StartContainerRequest.limits:type_name -> pb.ResourceLimits
+	4,  // 3: pb.StartContainerRequest.runtime:type_name -> pb.Runtime
+	3,  // 4: pb.StartContainerRequest.tests:type_name -> pb.TestInput
+	7,  // 5: pb.StartContainerResponse.compile:type_name -> pb.CompileResult
+	17, // 6: pb.StartContainerResponse.tests:type_name -> pb.TestOutcome
+	37, // 7: pb.ContainerInfo.created_at:type_name -> google.protobuf.Timestamp
+	13, // 8: pb.ListContainersResponse.containers:type_name -> pb.ContainerInfo
+	33, // 9: pb.RegisterWorkerRequest.labels:type_name -> pb.RegisterWorkerRequest.LabelsEntry
+	5,  // 10: pb.RegisterWorkerRequest.default_limits:type_name -> pb.ResourceLimits
+	37, // 11: pb.WorkerHealth.opened_at:type_name -> google.protobuf.Timestamp
+	34, // 12: pb.WorkerStatus.labels:type_name -> pb.WorkerStatus.LabelsEntry
+	37, // 13: pb.WorkerStatus.registered_at:type_name -> google.protobuf.Timestamp
+	37, // 14: pb.WorkerStatus.last_heartbeat:type_name -> google.protobuf.Timestamp
+	25, // 15: pb.WorkerStatus.health:type_name -> pb.WorkerHealth
+	26, // 16: pb.ListWorkersResponse.workers:type_name -> pb.WorkerStatus
+	35, // 17: pb.CheckPlacementRequest.node_selector:type_name -> pb.CheckPlacementRequest.NodeSelectorEntry
+	36, // 18: pb.CheckPlacementRequest.anti_affinity:type_name -> pb.CheckPlacementRequest.AntiAffinityEntry
+	2,  // 19: pb.WorkerService.StartContainer:input_type -> pb.StartContainerRequest
+	8,  // 20: pb.WorkerService.StopContainer:input_type -> pb.StopContainerRequest
+	0,  // 21: pb.WorkerService.WaitContainer:input_type -> pb.WaitContainerRequest
+	15, // 22: pb.WorkerService.GetLogs:input_type -> pb.GetLogsRequest
+	18, // 23: pb.WorkerService.StreamLogs:input_type -> pb.StreamLogsRequest
+	12, // 24: pb.WorkerService.ListContainers:input_type -> pb.ListContainersRequest
+	10, // 25: pb.WorkerService.RemoveContainer:input_type -> pb.RemoveContainerRequest
+	20, // 26: pb.ControlPlane.RegisterWorker:input_type -> pb.RegisterWorkerRequest
+	22, // 27: pb.ControlPlane.Heartbeat:input_type -> pb.HeartbeatRequest
+	24, // 28: pb.Orchestrator.ListWorkers:input_type -> pb.ListWorkersRequest
+	28, // 29: pb.Orchestrator.CheckPlacement:input_type -> pb.CheckPlacementRequest
+	30, // 30: pb.Orchestrator.StopJob:input_type -> pb.StopJobRequest
+	32, // 31: pb.Orchestrator.StreamJobLogs:input_type -> pb.StreamJobLogsRequest
+	6,  // 32: pb.WorkerService.StartContainer:output_type -> pb.StartContainerResponse
+	9,  // 33: pb.WorkerService.StopContainer:output_type -> pb.StopContainerResponse
+	1,  // 34: pb.WorkerService.WaitContainer:output_type -> pb.WaitContainerResponse
+	16, // 35: pb.WorkerService.GetLogs:output_type -> pb.GetLogsResponse
+	19, // 36: pb.WorkerService.StreamLogs:output_type -> pb.LogChunk
+	14, // 37: pb.WorkerService.ListContainers:output_type -> pb.ListContainersResponse
+	11, // 38: pb.WorkerService.RemoveContainer:output_type -> pb.RemoveContainerResponse
+	21, // 39: pb.ControlPlane.RegisterWorker:output_type -> pb.RegisterWorkerResponse
+	23, // 40: pb.ControlPlane.Heartbeat:output_type -> pb.HeartbeatResponse
+	27, // 41: pb.Orchestrator.ListWorkers:output_type -> pb.ListWorkersResponse
+	29, // 42: pb.Orchestrator.CheckPlacement:output_type -> pb.CheckPlacementResponse
+	31, // 43: pb.Orchestrator.StopJob:output_type -> pb.StopJobResponse
+	19, // 44: pb.Orchestrator.StreamJobLogs:output_type -> pb.LogChunk
+	32, // [32:45] is the sub-list for method output_type
+	19, // [19:32] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_service_proto_init() }
@@ -468,9 +2376,9 @@ func file_api_proto_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_service_proto_rawDesc), len(file_api_proto_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   37,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_api_proto_service_proto_goTypes,
 		DependencyIndexes: file_api_proto_service_proto_depIdxs,
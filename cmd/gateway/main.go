@@ -2,9 +2,16 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,12 +21,17 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/gorm"
 
-	"github.com/JullMol/nebula/internal/gateway/proxy"
-	"github.com/JullMol/nebula/internal/orchestrator/scheduler"
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/gateway/logstream"
+	"github.com/JullMol/nebula/internal/platform/archive"
 	"github.com/JullMol/nebula/internal/platform/database"
+	"github.com/JullMol/nebula/internal/platform/judge"
 	"github.com/JullMol/nebula/internal/platform/queue"
+	"github.com/JullMol/nebula/internal/platform/runtimes"
 	"github.com/JullMol/nebula/pkg/config"
 )
 
@@ -29,75 +41,55 @@ var (
 		Help: "Total jumlah job yang disubmit user",
 	})
 
-	jobsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
-		Name: "nebula_jobs_processed_total",
-		Help: "Total job selesai berdasarkan status",
-	}, []string{"status"})
+	jobsCancelled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "nebula_jobs_cancelled_total",
+		Help: "Total job yang dibatalkan user",
+	})
 )
 
+const judgeOverhead = 10 * time.Second
+
 func main() {
 	cfg, _ := config.LoadConfig()
 
-	dsn := "host=127.0.0.1 user=nebula password=nebula_password dbname=nebula_db port=5433 sslmode=disable TimeZone=Asia/Jakarta"
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		dsn = "host=127.0.0.1 user=nebula password=nebula_password dbname=nebula_db port=5433 sslmode=disable TimeZone=Asia/Jakarta"
+	}
 	db, err := database.NewConnection(dsn)
 	if err != nil {
 		log.Fatalf("❌ Gagal konek ke Database: %v", err)
 	}
 	fmt.Println("✅ Connected to PostgreSQL Database")
 
-	lb := scheduler.NewRoundRobin()
-	proxySvc := proxy.NewProxyService(lb, cfg.Server.Workers)
-	q := queue.NewRedisQueue(cfg.Server.RedisAddr)
+	conn, err := grpc.NewClient(cfg.Server.OrchestratorAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("❌ Gagal connect ke orchestrator %s: %v", cfg.Server.OrchestratorAddr, err)
+	}
+	defer conn.Close()
+	orchestrator := pb.NewOrchestratorClient(conn)
+	fmt.Printf("🧠 Orchestrator: %s\n", cfg.Server.OrchestratorAddr)
+
+	q := queue.NewRedisQueue(cfg.Server.RedisAddr, cfg.Server.QueueVisibilityTimeout)
 	fmt.Println("✅ Connected to Redis Queue")
 
+	runtimeRegistry, err := runtimes.NewRegistry(cfg.Runtimes)
+	if err != nil {
+		log.Fatalf("❌ Gagal load runtimes: %v", err)
+	}
+	fmt.Printf("🧩 %d runtime terdaftar\n", len(runtimeRegistry.List()))
+
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
 		fmt.Println("📊 Metrics server running on :3001")
 		http.ListenAndServe(":3001", nil)
 	}()
 
-	go func() {
-		fmt.Println("🚜 Background Dispatcher Started...")
-		for {
-			ctx := context.Background()
-			job, err := q.Dequeue(ctx)
-			if err != nil {
-				time.Sleep(1 * time.Second)
-				continue
-			}
-
-			fmt.Printf("🚜 Processing Job ID: %s (Image: %s)\n", job.ID, job.Image)
-
-			db.Model(&database.Job{}).Where("id = ?", job.ID).Update("status", "running")
-
-			resp, err := proxySvc.ForwardRunRequest(ctx, job.Image, job.Command, job.Code)
-
-			var resultLog string
-			var finalStatus string
+	archiveLimits := archive.LimitsFrom(cfg.Server.Archive)
 
-			if err != nil {
-				fmt.Printf("❌ Job %s Gagal: %v\n", job.ID, err)
-				resultLog = fmt.Sprintf("Error executing job: %v", err)
-				finalStatus = "failed"
-			} else {
-				proxySvc.ForwardWaitRequest(ctx, resp.ContainerId)
-				logs, _ := proxySvc.ForwardLogRequest(ctx, resp.ContainerId)
-				resultLog = strings.ReplaceAll(logs, "\x00", "")
-				finalStatus = "completed"
-			}
-			db.Model(&database.Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
-				"status": finalStatus,
-				"result": resultLog,
-				"updated_at": time.Now(),
-			})
-
-			jobsProcessed.WithLabelValues(finalStatus).Inc()
-
-			fmt.Printf("✅ Job %s Selesai. Status: %s\n", job.ID, finalStatus)
-		}
-	}()
-
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		BodyLimit: max(int(archiveLimits.MaxArchiveBytes)*4/3+1024*1024, fiber.DefaultBodyLimit),
+	})
 
 	app.Use(limiter.New(limiter.Config{
 		Max:          10,
@@ -109,7 +101,7 @@ func main() {
 	}))
 
 	app.Use(func(c *fiber.Ctx) error {
-		if c.Path() == "/" || c.Path() == "/metrics" || (len(c.Path()) > 7 && c.Path()[:7] == "/status") {
+		if c.Path() == "/" || c.Path() == "/metrics" || (len(c.Path()) > 7 && c.Path()[:7] == "/status") || strings.HasSuffix(c.Path(), "/logs/stream") {
 			return c.Next()
 		}
 		apiKey := c.Get("X-API-KEY")
@@ -120,23 +112,136 @@ func main() {
 	})
 
 	app.Post("/submit", func(c *fiber.Ctx) error {
+		type RetryReq struct {
+			MaxAttempts    int      `json:"max_attempts"`
+			BackoffMs      int64    `json:"backoff_ms"`
+			MaxBackoffMs   int64    `json:"max_backoff_ms"`
+			Multiplier     float64  `json:"multiplier"`
+			RetryableCodes []string `json:"retryable_codes"`
+		}
 		type Req struct {
-			Image   string `json:"image"`
-			Command string `json:"command"`
-			Code    string `json:"code"`
+			Language       string               `json:"language" form:"language"`
+			Version        string               `json:"version" form:"version"`
+			Command        string               `json:"command" form:"command"`
+			Code           string               `json:"code" form:"code"`
+			Archive        string               `json:"archive" form:"archive"`
+			Entrypoint     string               `json:"entrypoint" form:"entrypoint"`
+			Stdin          string               `json:"stdin" form:"stdin"`
+			Tests          []judge.TestCase     `json:"tests"`
+			TimeoutSeconds int                  `json:"timeout_seconds" form:"timeout_seconds"`
+			Limits         queue.ResourceLimits `json:"limits"`
+			Sandbox        string               `json:"sandbox" form:"sandbox"`
+			Retry          RetryReq             `json:"retry"`
+			NodeSelector   map[string]string    `json:"node_selector"`
+			AntiAffinity   map[string]string    `json:"anti_affinity"`
 		}
 		var p Req
 		if err := c.BodyParser(&p); err != nil {
 			return c.Status(400).SendString("Bad Request")
 		}
 
+		rt, err := runtimeRegistry.Resolve(p.Language, p.Version)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		bundle, err := readArchive(c, p.Archive, archiveLimits)
+		if err != nil {
+			return c.Status(archiveStatus(err)).JSON(fiber.Map{"error": err.Error()})
+		}
+		entrypoint := ""
+		if len(bundle) > 0 {
+			if p.Code != "" {
+				return c.Status(400).JSON(fiber.Map{"error": "code dan archive tidak bisa dipakai bersamaan"})
+			}
+			files, err := archive.List(bundle, archiveLimits)
+			if err != nil {
+				return c.Status(archiveStatus(err)).JSON(fiber.Map{"error": err.Error()})
+			}
+			if p.Entrypoint == "" {
+				p.Entrypoint = rt.FileName
+			}
+			entrypoint, err = archive.CleanPath(p.Entrypoint)
+			if err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+			}
+			if !slices.Contains(files, entrypoint) {
+				return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("entrypoint %s tidak ada di archive", p.Entrypoint)})
+			}
+		}
+
+		if err := judge.Validate(p.Tests); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		if len(p.Tests) > 0 && p.Stdin != "" {
+			return c.Status(400).JSON(fiber.Map{"error": "stdin tidak dipakai di mode judge, isi input di tiap test"})
+		}
+		if len(p.Stdin) > judge.MaxInputBytes {
+			return c.Status(413).JSON(fiber.Map{"error": fmt.Sprintf("stdin maksimal %d MB", judge.MaxInputBytes/(1024*1024))})
+		}
+
+		timeout := time.Duration(p.TimeoutSeconds) * time.Second
+		if timeout <= 0 {
+			timeout = cfg.Server.DefaultJobTimeout
+			if len(p.Tests) > 0 {
+				timeout = max(timeout, judge.Budget(p.Tests)+judgeOverhead)
+			}
+		}
+		if timeout < judge.Budget(p.Tests) {
+			return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("timeout_seconds minimal %d (total time_limit_ms semua test + %s per test)", int(math.Ceil(judge.Budget(p.Tests).Seconds())), judge.CaseOverhead)})
+		}
+		if cfg.Server.MaxJobTimeout > 0 && timeout > cfg.Server.MaxJobTimeout {
+			return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("timeout_seconds maksimal %d", int(cfg.Server.MaxJobTimeout.Seconds()))})
+		}
+
+		if len(p.NodeSelector) > 0 || len(p.AntiAffinity) > 0 {
+			check, err := orchestrator.CheckPlacement(context.Background(), &pb.CheckPlacementRequest{
+				NodeSelector: p.NodeSelector,
+				AntiAffinity: p.AntiAffinity,
+			})
+			if err != nil {
+				return c.Status(503).JSON(fiber.Map{"error": "Orchestrator tidak bisa dihubungi"})
+			}
+			if !check.Placeable {
+				return c.Status(422).JSON(fiber.Map{"error": "Tidak ada worker yang cocok dengan node_selector/anti_affinity"})
+			}
+		}
+
 		jobID := uuid.New().String()
 
+		job := queue.Job{
+			ID:             jobID,
+			Image:          rt.Image,
+			Runtime:        rt,
+			Command:        p.Command,
+			Code:           p.Code,
+			Archive:        bundle,
+			Entrypoint:     entrypoint,
+			Stdin:          p.Stdin,
+			Tests:          p.Tests,
+			TimeoutSeconds: int(timeout.Seconds()),
+			Limits:         p.Limits,
+			SandboxProfile: p.Sandbox,
+			Retry: queue.RetryPolicy{
+				MaxAttempts:    p.Retry.MaxAttempts,
+				InitialBackoff: time.Duration(p.Retry.BackoffMs) * time.Millisecond,
+				MaxBackoff:     time.Duration(p.Retry.MaxBackoffMs) * time.Millisecond,
+				Multiplier:     p.Retry.Multiplier,
+				RetryableCodes: p.Retry.RetryableCodes,
+			},
+			NodeSelector: p.NodeSelector,
+			AntiAffinity: p.AntiAffinity,
+		}
+		spec, _ := json.Marshal(job)
+
 		newJob := database.Job{
 			ID:        jobID,
-			Image:     p.Image,
+			Image:     rt.Image,
+			Language:  rt.Language,
+			Version:   rt.Version,
 			Command:   p.Command,
 			Status:    "queued",
+			Spec:      string(spec),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
@@ -144,14 +249,7 @@ func main() {
 			return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan ke database"})
 		}
 
-		err := q.Enqueue(context.Background(), queue.Job{
-			ID:      jobID,
-			Image:   p.Image,
-			Command: p.Command,
-			Code:    p.Code,
-		})
-
-		if err != nil {
+		if err := q.Enqueue(context.Background(), job); err != nil {
 			return c.Status(500).JSON(fiber.Map{"error": "Failed to enqueue"})
 		}
 
@@ -178,14 +276,201 @@ func main() {
 		}
 
 		return c.JSON(fiber.Map{
-			"job_id":     job.ID,
-			"status":     job.Status,
-			"result":     job.Result,
-			"created_at": job.CreatedAt,
-			"updated_at": job.UpdatedAt,
+			"job_id":         job.ID,
+			"status":         job.Status,
+			"language":       job.Language,
+			"version":        job.Version,
+			"result":         job.Result,
+			"stdout":         job.Stdout,
+			"stderr":         job.Stderr,
+			"exit_code":      job.ExitCode,
+			"started_at":     job.StartedAt,
+			"finished_at":    job.FinishedAt,
+			"duration_ms":    job.DurationMs,
+			"attempts":       job.Attempts,
+			"worker_addr":    job.WorkerAddr,
+			"compile_output": job.CompileOutput,
+			"compile_ms":     job.CompileMs,
+			"judge":          judgeReport(job.Judge),
+			"created_at":     job.CreatedAt,
+			"updated_at":     job.UpdatedAt,
+		})
+	})
+
+	app.Post("/jobs/:id/cancel", func(c *fiber.Ctx) error {
+		jobID := c.Params("id")
+		ctx := context.Background()
+
+		res := db.Model(&database.Job{}).
+			Where("id = ? AND status IN ?", jobID, []string{"queued", "retrying", "running"}).
+			Updates(map[string]interface{}{
+				"status":     "cancelled",
+				"result":     "Job dibatalkan oleh user",
+				"updated_at": time.Now(),
+			})
+		if res.Error != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Database error"})
+		}
+		if res.RowsAffected == 0 {
+			var job database.Job
+			if err := db.First(&job, "id = ?", jobID).Error; err != nil {
+				return c.Status(404).JSON(fiber.Map{"error": "Job tidak ditemukan"})
+			}
+			return c.Status(409).JSON(fiber.Map{"error": fmt.Sprintf("Job sudah %s, tidak bisa dibatalkan", job.Status)})
+		}
+
+		if err := q.Remove(ctx, jobID); err != nil && err != queue.ErrNotFound {
+			fmt.Printf("⚠️ Gagal hapus job %s dari queue: %v\n", jobID, err)
+		}
+
+		if _, err := orchestrator.StopJob(ctx, &pb.StopJobRequest{JobId: jobID}); err != nil {
+			fmt.Printf("⚠️ Gagal stop container job %s: %v\n", jobID, err)
+		}
+
+		jobsCancelled.Inc()
+
+		return c.JSON(fiber.Map{
+			"status": "cancelled",
+			"job_id": jobID,
+		})
+	})
+
+	app.Get("/jobs/:id/logs/stream", logstream.Handler(db, orchestrator))
+
+	app.Get("/runtimes", func(c *fiber.Ctx) error {
+		list := runtimeRegistry.List()
+		return c.JSON(fiber.Map{"count": len(list), "runtimes": list})
+	})
+
+	app.Get("/workers", func(c *fiber.Ctx) error {
+		resp, err := orchestrator.ListWorkers(context.Background(), &pb.ListWorkersRequest{})
+		if err != nil {
+			return c.Status(503).JSON(fiber.Map{"error": "Orchestrator tidak bisa dihubungi"})
+		}
+
+		workers := make([]fiber.Map, 0, len(resp.Workers))
+		health := fiber.Map{}
+		for _, w := range resp.Workers {
+			workers = append(workers, fiber.Map{
+				"name":            w.Name,
+				"address":         w.Address,
+				"capacity":        w.Capacity,
+				"labels":          w.Labels,
+				"running":         w.Running,
+				"static":          w.Static,
+				"weight":          w.Weight,
+				"total_memory_mb": w.TotalMemoryMb,
+				"total_cpus":      w.TotalCpus,
+				"free_memory_mb":  w.FreeMemoryMb,
+				"free_cpus":       w.FreeCpus,
+				"images":          w.Images,
+				"registered_at":   w.RegisteredAt.AsTime(),
+				"last_heartbeat":  w.LastHeartbeat.AsTime(),
+			})
+			if w.Health != nil {
+				entry := fiber.Map{
+					"state":                w.Health.State,
+					"consecutive_failures": w.Health.ConsecutiveFailures,
+					"last_error":           w.Health.LastError,
+				}
+				if w.Health.OpenedAt != nil {
+					entry["opened_at"] = w.Health.OpenedAt.AsTime()
+				}
+				health[w.Address] = entry
+			}
+		}
+		return c.JSON(fiber.Map{"count": len(workers), "workers": workers, "health": health})
+	})
+
+	app.Get("/dlq", func(c *fiber.Ctx) error {
+		letters, err := q.DeadLetters(context.Background())
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal baca dead-letter queue"})
+		}
+
+		items := make([]fiber.Map, 0, len(letters))
+		for _, dl := range letters {
+			items = append(items, fiber.Map{
+				"job_id":    dl.Job.ID,
+				"language":  dl.Job.Runtime.Language,
+				"version":   dl.Job.Runtime.Version,
+				"image":     dl.Job.Image,
+				"command":   dl.Job.Command,
+				"reason":    dl.Reason,
+				"failed_at": dl.FailedAt,
+			})
+		}
+		return c.JSON(fiber.Map{"count": len(items), "jobs": items})
+	})
+
+	app.Post("/dlq/:job_id/redrive", func(c *fiber.Ctx) error {
+		jobID := c.Params("job_id")
+
+		reset := db.Model(&database.Job{}).Where("id = ? AND status = ?", jobID, "failed").Updates(map[string]interface{}{
+			"status":     "queued",
+			"attempts":   0,
+			"updated_at": time.Now(),
+		})
+		if reset.Error != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Database error"})
+		}
+		if reset.RowsAffected == 0 {
+			return c.Status(409).JSON(fiber.Map{"error": "Job tidak dalam status failed"})
+		}
+
+		job, err := q.Redrive(context.Background(), jobID)
+		if err != nil {
+			db.Model(&database.Job{}).Where("id = ?", jobID).Update("status", "failed")
+			if err == queue.ErrNotFound {
+				return c.Status(404).JSON(fiber.Map{"error": "Job tidak ada di dead-letter queue"})
+			}
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal redrive job"})
+		}
+
+		return c.JSON(fiber.Map{
+			"status": "queued",
+			"job_id": job.ID,
+			"info":   "Job dikirim ulang ke queue",
 		})
 	})
 
 	app.Static("/", "./cmd/gateway/index.html")
 	log.Fatal(app.Listen(cfg.Server.Port))
+}
+
+func readArchive(c *fiber.Ctx, encoded string, limits archive.Limits) ([]byte, error) {
+	if fh, err := c.FormFile("archive"); err == nil {
+		if fh.Size > limits.MaxArchiveBytes {
+			return nil, fmt.Errorf("%w (%d MB)", archive.ErrArchiveTooLarge, limits.MaxArchiveBytes/(1024*1024))
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return nil, fmt.Errorf("gagal baca archive: %w", err)
+		}
+		defer f.Close()
+		return io.ReadAll(f)
+	}
+
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("archive harus base64 yang valid")
+	}
+	return data, nil
+}
+
+func archiveStatus(err error) int {
+	if errors.Is(err, archive.ErrArchiveTooLarge) || errors.Is(err, archive.ErrExtractedTooLarge) || errors.Is(err, archive.ErrTooManyFiles) {
+		return 413
+	}
+	return 400
+}
+
+func judgeReport(raw string) json.RawMessage {
+	if raw == "" {
+		return nil
+	}
+	return json.RawMessage(raw)
 }
\ No newline at end of file
@@ -1,55 +1,100 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
-	"time"
+	"strings"
 )
 
-type RunRequest struct {
-	Image   string `json:"image"`
-	Command string `json:"command"`
+type SubmitRequest struct {
+	Language string `json:"language"`
+	Version  string `json:"version,omitempty"`
+	Command  string `json:"command"`
+	Code     string `json:"code"`
+	Stdin    string `json:"stdin,omitempty"`
 }
 
-type RunResponse struct {
-	Status      string `json:"status"`
-	ContainerID string `json:"container_id"`
-	Message     string `json:"message"`
-	Error       string `json:"error,omitempty"`
+type SubmitResponse struct {
+	Status string `json:"status"`
+	JobID  string `json:"job_id"`
+	Error  string `json:"error,omitempty"`
+}
+
+type LogEvent struct {
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+type DoneEvent struct {
+	Status     string `json:"status"`
+	ExitCode   *int   `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
 }
 
 func main() {
-	imagePtr := flag.String("image", "alpine", "Docker image to use (default: alpine)")
+	langPtr := flag.String("lang", "shell", "Language runtime to use (default: shell)")
+	versionPtr := flag.String("version", "", "Runtime version (default: runtime default)")
 	cmdPtr := flag.String("cmd", "", "Command to run inside container")
+	filePtr := flag.String("file", "", "Source file to execute")
+	stdinPtr := flag.String("stdin", "", "File whose content is fed to the program's stdin")
+	gatewayPtr := flag.String("gateway", "http://localhost:3000", "Gateway URL")
+	keyPtr := flag.String("key", "rahasia-negara", "API key")
 	flag.Parse()
 
-	if *cmdPtr == "" {
-		fmt.Println("❌ Error: Command tidak boleh kosong.")
+	if *cmdPtr == "" && *filePtr == "" {
+		fmt.Println("❌ Error: Isi -cmd atau -file.")
 		fmt.Println("👉 Contoh: nebula-cli -cmd \"echo hello\"")
+		fmt.Println("👉 Contoh: nebula-cli -lang python -file main.py")
 		os.Exit(1)
 	}
 
-	gatewayURL := "http://localhost:3000"
-	fmt.Printf("🚀 Deploying function to Nebula... (Image: %s)\n", *imagePtr)
+	var code string
+	if *filePtr != "" {
+		data, err := os.ReadFile(*filePtr)
+		if err != nil {
+			fmt.Printf("❌ Gagal baca file: %v\n", err)
+			os.Exit(1)
+		}
+		code = string(data)
+	}
+
+	var stdin string
+	if *stdinPtr != "" {
+		data, err := os.ReadFile(*stdinPtr)
+		if err != nil {
+			fmt.Printf("❌ Gagal baca file stdin: %v\n", err)
+			os.Exit(1)
+		}
+		stdin = string(data)
+	}
 
-	reqBody, _ := json.Marshal(RunRequest{
-		Image:   *imagePtr,
-		Command: *cmdPtr,
+	fmt.Printf("🚀 Deploying function to Nebula... (Runtime: %s %s)\n", *langPtr, *versionPtr)
+
+	reqBody, _ := json.Marshal(SubmitRequest{
+		Language: *langPtr,
+		Version:  *versionPtr,
+		Command:  *cmdPtr,
+		Code:     code,
+		Stdin:    stdin,
 	})
 
-	resp, err := http.Post(gatewayURL+"/run", "application/json", bytes.NewBuffer(reqBody))
+	req, _ := http.NewRequest(http.MethodPost, *gatewayPtr+"/submit", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-KEY", *keyPtr)
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		fmt.Printf("❌ Gagal menghubungi Gateway: %v\n", err)
 		os.Exit(1)
 	}
 	defer resp.Body.Close()
 
-	var result RunResponse
+	var result SubmitResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		fmt.Printf("❌ Gagal parsing response: %v\n", err)
 		os.Exit(1)
@@ -60,21 +105,63 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Success! Container ID: %s\n", result.ContainerID)
-	fmt.Println("⏳ Waiting for logs...")
-
-	time.Sleep(2 * time.Second)
+	fmt.Printf("✅ Job queued! Job ID: %s\n", result.JobID)
+	fmt.Println("================ OUTPUT ================")
 
-	logResp, err := http.Get(fmt.Sprintf("%s/logs/%s", gatewayURL, result.ContainerID))
+	done, err := tailLogs(*gatewayPtr, result.JobID)
+	fmt.Println("========================================")
 	if err != nil {
-		fmt.Printf("⚠️ Gagal mengambil logs: %v\n", err)
-		return
+		fmt.Printf("⚠️ Stream log terputus: %v\n", err)
+		os.Exit(1)
 	}
-	defer logResp.Body.Close()
 
-	logs, _ := io.ReadAll(logResp.Body)
+	fmt.Printf("🏁 Status: %s (%d ms)\n", done.Status, done.DurationMs)
+	if done.ExitCode != nil {
+		os.Exit(*done.ExitCode)
+	}
+	if done.Status != "completed" {
+		os.Exit(1)
+	}
+}
 
-	fmt.Println("================ OUTPUT ================")
-	fmt.Println(string(logs))
-	fmt.Println("========================================")
-}
\ No newline at end of file
+func tailLogs(gatewayURL, jobID string) (*DoneEvent, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/jobs/%s/logs/stream", gatewayURL, jobID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var event string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data := []byte(strings.TrimPrefix(line, "data: "))
+			switch event {
+			case "log":
+				var chunk LogEvent
+				if json.Unmarshal(data, &chunk) == nil {
+					if chunk.Stream == "stderr" {
+						fmt.Fprint(os.Stderr, chunk.Data)
+					} else {
+						fmt.Print(chunk.Data)
+					}
+				}
+			case "done":
+				var done DoneEvent
+				if err := json.Unmarshal(data, &done); err != nil {
+					return nil, err
+				}
+				return &done, nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("stream selesai tanpa status akhir")
+}
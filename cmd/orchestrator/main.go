@@ -4,40 +4,97 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
+	"net"
+	"net/http"
+	"os"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/orchestrator/dispatcher"
+	"github.com/JullMol/nebula/internal/orchestrator/proxy"
+	"github.com/JullMol/nebula/internal/orchestrator/reconciler"
+	"github.com/JullMol/nebula/internal/orchestrator/registry"
+	"github.com/JullMol/nebula/internal/orchestrator/scheduler"
+	"github.com/JullMol/nebula/internal/orchestrator/service"
+	"github.com/JullMol/nebula/internal/platform/database"
+	"github.com/JullMol/nebula/internal/platform/leader"
+	"github.com/JullMol/nebula/internal/platform/queue"
+	"github.com/JullMol/nebula/pkg/config"
 )
 
 func main() {
 	fmt.Println("🧠 Nebula Orchestrator Starting...")
 
-	target := "localhost:9090"
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("❌ Gagal connect ke worker: %v", err)
+		log.Fatalf("❌ Gagal baca config: %v", err)
 	}
-	defer conn.Close()
 
-	client := pb.NewWorkerServiceClient(conn)
-	fmt.Printf("✅ Terhubung ke Worker di %s\n", target)
-
-	fmt.Println("📤 Mengirim perintah StartContainer...")
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		dsn = "host=127.0.0.1 user=nebula password=nebula_password dbname=nebula_db port=5433 sslmode=disable TimeZone=Asia/Jakarta"
+	}
+	db, err := database.NewConnection(dsn)
+	if err != nil {
+		log.Fatalf("❌ Gagal konek ke Database: %v", err)
+	}
+	fmt.Println("✅ Connected to PostgreSQL Database")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	workerRegistry := registry.NewRegistry(cfg.Server.Workers, cfg.Server.HeartbeatTTL)
+	go workerRegistry.Run(context.Background())
 
-	resp, err := client.StartContainer(ctx, &pb.StartContainerRequest{
-		Image:   "alpine",
-		Command: "echo 'Halo Bos! Perintah diterima via gRPC!'",
-	})
+	health := scheduler.NewHealthTracker(cfg.Server.Health.FailureThreshold, cfg.Server.Health.OpenDuration)
+	go health.RunChecks(context.Background(), cfg.Server.Health.CheckInterval, workerRegistry.Workers)
 
+	load := scheduler.NewLoadTracker()
+	policy, err := scheduler.NewPolicy(cfg.Server.Scheduler.Policy)
 	if err != nil {
-		log.Fatalf("❌ Gagal memanggil RPC: %v", err)
+		log.Fatalf("❌ Gagal init scheduler: %v", err)
 	}
+	sched := scheduler.NewScheduler(policy, registry.NewStateSource(workerRegistry, load), health)
+	fmt.Printf("🧭 Scheduler policy: %s\n", sched.Policy())
+	proxySvc := proxy.NewProxyService(sched, workerRegistry, health, load)
 
-	fmt.Printf("✅ Worker merespon: ContainerID=%s\n", resp.ContainerId)
-}
\ No newline at end of file
+	q := queue.NewRedisQueue(cfg.Server.RedisAddr, cfg.Server.QueueVisibilityTimeout)
+	fmt.Println("✅ Connected to Redis Queue")
+
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		fmt.Printf("📊 Metrics server running on %s\n", cfg.Orchestrator.MetricsPort)
+		http.ListenAndServe(cfg.Orchestrator.MetricsPort, nil)
+	}()
+
+	retryDefaults := queue.RetryPolicy{
+		MaxAttempts:    cfg.Server.Retry.MaxAttempts,
+		InitialBackoff: cfg.Server.Retry.InitialBackoff,
+		MaxBackoff:     cfg.Server.Retry.MaxBackoff,
+		Multiplier:     cfg.Server.Retry.Multiplier,
+		RetryableCodes: cfg.Server.Retry.RetryableCodes,
+	}
+	slots := workerRegistry.TotalCapacity
+	if n := cfg.Orchestrator.DispatchConcurrency; n > 0 {
+		slots = func() int { return n }
+	}
+	disp := dispatcher.NewDispatcher(db, q, proxySvc, retryDefaults, slots)
+	go disp.Run(context.Background())
+
+	elector := leader.NewElector(cfg.Server.RedisAddr, "orchestrator", cfg.Orchestrator.LeaderLease)
+	rec := reconciler.NewReconciler(db, q, proxySvc, disp, workerRegistry, elector, retryDefaults, cfg.Orchestrator.ReconcileInterval, cfg.Orchestrator.ReconcileStaleAfter)
+	elector.OnElected(rec.Run)
+	go elector.Run(context.Background())
+	fmt.Printf("🗳️ Ikut leader election sebagai %s\n", elector.ID())
+
+	lis, err := net.Listen("tcp", cfg.Server.ControlPlanePort)
+	if err != nil {
+		log.Fatalf("❌ Gagal listen control plane %s: %v", cfg.Server.ControlPlanePort, err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterControlPlaneServer(grpcServer, registry.NewServer(workerRegistry))
+	pb.RegisterOrchestratorServer(grpcServer, service.NewServer(db, workerRegistry, health, proxySvc))
+	fmt.Printf("🛰️ Control plane gRPC running on %s\n", cfg.Server.ControlPlanePort)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("❌ Gagal serve control plane: %v", err)
+	}
+}
@@ -1,26 +1,37 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	pb "github.com/JullMol/nebula/api/pb"
 	"github.com/JullMol/nebula/internal/platform/docker"
 	"github.com/JullMol/nebula/internal/worker"
+	"github.com/JullMol/nebula/pkg/config"
 )
 
 func main() {
 	portPtr := flag.String("port", "9090", "Port untuk Worker")
+	advertisePtr := flag.String("advertise", "", "Alamat worker yang diumumkan ke control plane (default: localhost:<port>)")
 	flag.Parse()
 
 	port := fmt.Sprintf(":%s", *portPtr)
 
 	fmt.Printf("⚡ Nebula Worker Node Starting on Port %s...\n", port)
 
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Printf("⚠️ Config tidak terbaca, pakai default: %v\n", err)
+		cfg = &config.Config{}
+	}
+
 	dockerCli, err := docker.NewClient()
 	if err != nil {
 		log.Fatalf("❌ Gagal konek Docker: %v", err)
@@ -31,13 +42,47 @@ func main() {
 		log.Fatalf("❌ Gagal listen port %s: %v", port, err)
 	}
 
-	grpcServer := grpc.NewServer()
-	workerServer := worker.NewServer(dockerCli)
+	workerServer, err := worker.NewServer(dockerCli, cfg.Worker)
+	if err != nil {
+		log.Fatalf("❌ Gagal init worker: %v", err)
+	}
+	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(workerServer.MaxRecvMsgSize()))
 	pb.RegisterWorkerServiceServer(grpcServer, workerServer)
 
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	go worker.WatchDocker(context.Background(), dockerCli, healthServer)
+	go workerServer.RunSweeper(context.Background())
+	if cfg.Worker.PrepullImages {
+		go workerServer.Prepull(context.Background(), runtimeImages(cfg.Runtimes))
+	}
+
+	advertise := *advertisePtr
+	if advertise == "" {
+		advertise = cfg.Worker.AdvertiseAddr
+	}
+	if advertise == "" {
+		advertise = fmt.Sprintf("localhost%s", port)
+	}
+	if cfg.Worker.ControlPlaneAddr != "" {
+		go worker.NewAgent(cfg.Worker, advertise, workerServer).Run(context.Background())
+	}
+
 	fmt.Printf("🚀 Worker siap di %s\n", port)
 
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("❌ Gagal serve gRPC: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+func runtimeImages(runtimes []config.RuntimeConfig) []string {
+	seen := make(map[string]bool)
+	var images []string
+	for _, rt := range runtimes {
+		if rt.Image != "" && !seen[rt.Image] {
+			seen[rt.Image] = true
+			images = append(images, rt.Image)
+		}
+	}
+	return images
+}
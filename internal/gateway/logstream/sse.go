@@ -0,0 +1,140 @@
+package logstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/database"
+)
+
+const (
+	pollInterval  = 500 * time.Millisecond
+	finalizeLimit = 10 * time.Second
+)
+
+func Handler(db *gorm.DB, orchestrator pb.OrchestratorClient) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		jobID := c.Params("id")
+
+		var job database.Job
+		if err := db.First(&job, "id = ?", jobID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(404).JSON(fiber.Map{"error": "Job tidak ditemukan"})
+			}
+			return c.Status(500).JSON(fiber.Map{"error": "Database error"})
+		}
+
+		c.Set("Content-Type", "text/event-stream")
+		c.Set("Cache-Control", "no-cache")
+		c.Set("Connection", "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			send := func(event string, payload interface{}) error {
+				data, _ := json.Marshal(payload)
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+				if err := w.Flush(); err != nil {
+					cancel()
+					return err
+				}
+				return nil
+			}
+
+			placement, err := waitForContainer(ctx, db, jobID, send)
+			if err != nil {
+				return
+			}
+
+			if placement.ContainerID != "" {
+				err := streamLogs(ctx, orchestrator, jobID, func(chunk *pb.LogChunk) error {
+					return send("log", fiber.Map{"stream": chunk.Stream, "data": chunk.Data})
+				})
+				if err != nil && ctx.Err() == nil {
+					send("error", fiber.Map{"error": err.Error()})
+				}
+			}
+
+			final := waitForFinal(ctx, db, jobID)
+			send("done", fiber.Map{
+				"status":      final.Status,
+				"exit_code":   final.ExitCode,
+				"duration_ms": final.DurationMs,
+			})
+		})
+		return nil
+	}
+}
+
+func streamLogs(ctx context.Context, orchestrator pb.OrchestratorClient, jobID string, onChunk func(*pb.LogChunk) error) error {
+	stream, err := orchestrator.StreamJobLogs(ctx, &pb.StreamJobLogsRequest{JobId: jobID})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := onChunk(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func waitForContainer(ctx context.Context, db *gorm.DB, jobID string, send func(string, interface{}) error) (*database.Job, error) {
+	lastStatus := ""
+	for {
+		var job database.Job
+		if err := db.First(&job, "id = ?", jobID).Error; err != nil {
+			send("error", fiber.Map{"error": "Job tidak ditemukan"})
+			return nil, err
+		}
+
+		if job.Status != lastStatus {
+			if err := send("status", fiber.Map{"status": job.Status}); err != nil {
+				return nil, err
+			}
+			lastStatus = job.Status
+		}
+
+		if job.ContainerID != "" || database.IsTerminalStatus(job.Status) {
+			return &job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func waitForFinal(ctx context.Context, db *gorm.DB, jobID string) database.Job {
+	deadline := time.Now().Add(finalizeLimit)
+	var job database.Job
+	for {
+		db.First(&job, "id = ?", jobID)
+		if database.IsTerminalStatus(job.Status) || time.Now().After(deadline) {
+			return job
+		}
+		select {
+		case <-ctx.Done():
+			return job
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/orchestrator/proxy"
+	"github.com/JullMol/nebula/internal/orchestrator/scheduler"
+	"github.com/JullMol/nebula/internal/platform/database"
+	"github.com/JullMol/nebula/internal/platform/judge"
+	"github.com/JullMol/nebula/internal/platform/queue"
+)
+
+var (
+	jobsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nebula_jobs_processed_total",
+		Help: "Total job selesai berdasarkan status",
+	}, []string{"status"})
+
+	jobsRetried = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "nebula_jobs_retried_total",
+		Help: "Total job yang dijadwalkan ulang karena error sementara",
+	})
+
+	jobsDeadLettered = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "nebula_jobs_dead_lettered_total",
+		Help: "Total job yang masuk dead-letter queue",
+	})
+
+	jobsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "nebula_dispatcher_in_flight_jobs",
+		Help: "Jumlah job yang sedang diproses dispatcher",
+	})
+
+	poolSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "nebula_dispatcher_pool_size",
+		Help: "Jumlah slot dispatch yang tersedia",
+	})
+
+	dispatchSaturated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "nebula_dispatcher_saturated_total",
+		Help: "Berapa kali dispatcher berhenti ambil job karena semua slot penuh",
+	})
+)
+
+const (
+	defaultWaitTimeout = 60 * time.Second
+	waitGrace          = 30 * time.Second
+	cancelPollInterval = time.Second
+)
+
+type Dispatcher struct {
+	db          *gorm.DB
+	queue       queue.QueueSystem
+	proxy       *proxy.ProxyService
+	retryPolicy queue.RetryPolicy
+	pool        *pool
+}
+
+func NewDispatcher(db *gorm.DB, q queue.QueueSystem, proxySvc *proxy.ProxyService, retryPolicy queue.RetryPolicy, slots func() int) *Dispatcher {
+	return &Dispatcher{
+		db:          db,
+		queue:       q,
+		proxy:       proxySvc,
+		retryPolicy: retryPolicy,
+		pool:        newPool(slots),
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	fmt.Println("🚜 Background Dispatcher Started...")
+	defer d.pool.wait()
+	for {
+		if !d.pool.acquire(ctx) {
+			return
+		}
+
+		job, err := d.queue.Dequeue(ctx)
+		if err != nil {
+			d.pool.release()
+			if ctx.Err() != nil {
+				return
+			}
+			time.Sleep(1 * time.Second)
+			continue
+		}
+
+		go func() {
+			defer d.pool.release()
+			d.process(ctx, job)
+		}()
+	}
+}
+
+func (d *Dispatcher) process(ctx context.Context, job *queue.Job) {
+	var existing database.Job
+	if err := d.db.First(&existing, "id = ?", job.ID).Error; err == nil && database.IsTerminalStatus(existing.Status) {
+		fmt.Printf("⏭️ Job %s sudah %s, skip & ack\n", job.ID, existing.Status)
+		d.queue.Ack(ctx, job)
+		return
+	}
+
+	if existing.Status == "running" {
+		if !d.takeOver(&existing) {
+			fmt.Printf("⏭️ Job %s masih dipegang dispatcher lain, skip & ack\n", job.ID)
+			d.queue.Ack(ctx, job)
+			return
+		}
+		if existing.ContainerID != "" {
+			fmt.Printf("🔗 Job %s di-redeliver, re-attach ke container %s\n", job.ID, existing.ContainerID)
+			d.attach(ctx, job, &existing)
+			return
+		}
+	}
+
+	fmt.Printf("🚜 Processing Job ID: %s (Runtime: %s %s, Image: %s)\n", job.ID, job.Runtime.Language, job.Runtime.Version, job.Image)
+
+	stopExtend := make(chan struct{})
+	defer close(stopExtend)
+	go d.extendLease(ctx, job, stopExtend)
+
+	attempts := existing.Attempts + 1
+	claim := d.db.Model(&database.Job{}).Where("id = ? AND status <> ?", job.ID, "cancelled").Updates(map[string]interface{}{
+		"status":   "running",
+		"attempts": attempts,
+	})
+	if claim.RowsAffected == 0 {
+		fmt.Printf("⏭️ Job %s sudah dibatalkan, skip & ack\n", job.ID)
+		d.queue.Ack(ctx, job)
+		return
+	}
+
+	policy := MergePolicy(job.Retry, d.retryPolicy)
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	if len(job.Tests) > 0 {
+		go d.watchCancel(runCtx, job.ID, cancelRun)
+	}
+
+	resp, workerAddr, err := d.proxy.ForwardRunRequest(runCtx, &pb.StartContainerRequest{
+		JobId:          job.ID,
+		Image:          job.Image,
+		Command:        job.Command,
+		Code:           job.Code,
+		Archive:        job.Archive,
+		Entrypoint:     job.Entrypoint,
+		Stdin:          job.Stdin,
+		Tests:          testInputs(job.Tests),
+		TimeoutSeconds: int32(job.TimeoutSeconds),
+		Limits: &pb.ResourceLimits{
+			MemoryMb:  job.Limits.MemoryMB,
+			Cpus:      job.Limits.CPUs,
+			PidsLimit: job.Limits.PidsLimit,
+			TmpfsMb:   job.Limits.TmpfsMB,
+		},
+		SandboxProfile: job.SandboxProfile,
+		Runtime: &pb.Runtime{
+			Language:              job.Runtime.Language,
+			Version:               job.Runtime.Version,
+			FileName:              job.Runtime.FileName,
+			RunCommand:            job.Runtime.RunCommand,
+			CompileCommand:        job.Runtime.CompileCommand,
+			CompileTimeoutSeconds: int32(job.Runtime.CompileTimeout.Seconds()),
+		},
+	}, scheduler.Constraints{
+		NodeSelector: job.NodeSelector,
+		AntiAffinity: job.AntiAffinity,
+	})
+	if err != nil {
+		if ctx.Err() == nil && runCtx.Err() != nil {
+			fmt.Printf("🛑 Job %s dibatalkan saat judge berjalan\n", job.ID)
+			d.queue.Ack(ctx, job)
+			jobsProcessed.WithLabelValues("cancelled").Inc()
+			return
+		}
+		d.handleFailure(ctx, job, policy, attempts, err)
+		return
+	}
+
+	if resp.Compile != nil && !resp.Compile.Success {
+		fmt.Printf("🧱 Job %s gagal dikompilasi (exit %d)\n", job.ID, resp.Compile.ExitCode)
+		d.finish(ctx, job, map[string]interface{}{
+			"status":         "compile_error",
+			"exit_code":      int(resp.Compile.ExitCode),
+			"result":         resp.Compile.Output,
+			"stderr":         resp.Compile.Output,
+			"compile_output": resp.Compile.Output,
+			"compile_ms":     resp.Compile.DurationMs,
+			"worker_addr":    workerAddr,
+		})
+		return
+	}
+
+	if len(job.Tests) > 0 {
+		d.judge(ctx, job, resp, workerAddr)
+		return
+	}
+
+	defer d.proxy.Forget(resp.ContainerId)
+
+	fields := map[string]interface{}{
+		"container_id": resp.ContainerId,
+		"worker_addr":  workerAddr,
+	}
+	if resp.Compile != nil {
+		fields["compile_output"] = resp.Compile.Output
+		fields["compile_ms"] = resp.Compile.DurationMs
+	}
+	placed := d.db.Model(&database.Job{}).Where("id = ? AND status <> ?", job.ID, "cancelled").Updates(fields)
+	if placed.RowsAffected == 0 {
+		fmt.Printf("🛑 Job %s dibatalkan saat start, stop container %s\n", job.ID, resp.ContainerId)
+		d.proxy.ForwardStopRequest(ctx, resp.ContainerId)
+		d.queue.Ack(ctx, job)
+		return
+	}
+
+	d.await(ctx, job, resp.ContainerId, policy, attempts)
+}
+
+func (d *Dispatcher) Attach(ctx context.Context, existing *database.Job) {
+	job := &queue.Job{ID: existing.ID}
+	if existing.Spec != "" {
+		json.Unmarshal([]byte(existing.Spec), job)
+	}
+	job.MessageID = ""
+	d.attach(ctx, job, existing)
+}
+
+func (d *Dispatcher) attach(ctx context.Context, job *queue.Job, existing *database.Job) {
+	stopExtend := make(chan struct{})
+	defer close(stopExtend)
+	go d.extendLease(ctx, job, stopExtend)
+
+	d.proxy.Track(existing.ContainerID, existing.WorkerAddr)
+	defer d.proxy.Forget(existing.ContainerID)
+
+	d.await(ctx, job, existing.ContainerID, MergePolicy(job.Retry, d.retryPolicy), existing.Attempts)
+}
+
+func (d *Dispatcher) await(ctx context.Context, job *queue.Job, containerID string, policy queue.RetryPolicy, attempts int) {
+	finalStatus := "completed"
+	waitResp, err := d.proxy.ForwardWaitRequest(ctx, containerID, waitTimeout(job))
+	if err != nil && isLost(err) {
+		d.handleFailure(ctx, job, policy, attempts, status.Errorf(codes.Unavailable, "container %s hilang: %v", containerID, err))
+		return
+	}
+	if err != nil {
+		fmt.Printf("⚠️ Gagal menunggu container job %s: %v\n", job.ID, err)
+		finalStatus = "failed"
+	} else if waitResp.TimedOut {
+		fmt.Printf("⏰ Job %s melewati batas waktu %ds\n", job.ID, job.TimeoutSeconds)
+		finalStatus = "timed_out"
+	} else if waitResp.OomKilled {
+		fmt.Printf("💥 Job %s kehabisan memori (OOM killed)\n", job.ID)
+		finalStatus = "oom_killed"
+	}
+
+	fields := map[string]interface{}{"status": finalStatus}
+	if waitResp != nil {
+		fields["exit_code"] = int(waitResp.ExitCode)
+		if waitResp.StartedAt != nil && waitResp.FinishedAt != nil {
+			fields["started_at"] = waitResp.StartedAt.AsTime()
+			fields["finished_at"] = waitResp.FinishedAt.AsTime()
+			fields["duration_ms"] = waitResp.DurationMs
+		}
+	}
+	if logs, err := d.proxy.ForwardLogRequest(ctx, containerID); err == nil {
+		fields["result"] = logs.Logs
+		fields["stdout"] = logs.Stdout
+		fields["stderr"] = logs.Stderr
+	}
+	d.finish(ctx, job, fields)
+
+	if err := d.proxy.ForwardRemoveRequest(ctx, containerID); err != nil && status.Code(err) != codes.NotFound {
+		fmt.Printf("⚠️ Gagal hapus container %s job %s: %v\n", containerID, job.ID, err)
+	}
+}
+
+func (d *Dispatcher) judge(ctx context.Context, job *queue.Job, resp *pb.StartContainerResponse, workerAddr string) {
+	outcomes := make([]judge.Outcome, len(job.Tests))
+	var durationMs int64
+	for _, r := range resp.Tests {
+		if int(r.Index) >= len(outcomes) {
+			continue
+		}
+		outcomes[r.Index] = judge.Outcome{
+			Stdout:    r.Stdout,
+			Stderr:    r.Stderr,
+			ExitCode:  int(r.ExitCode),
+			TimeMs:    r.TimeMs,
+			MemoryKB:  r.MemoryKb,
+			TimedOut:  r.TimedOut,
+			OOMKilled: r.OomKilled,
+			Ran:       true,
+		}
+		durationMs += r.TimeMs
+	}
+
+	report := judge.Evaluate(job.Tests, outcomes)
+	fmt.Printf("⚖️ Job %s: %s (%d/%d test lolos)\n", job.ID, report.Verdict, report.Passed, report.Total)
+
+	finalStatus := "completed"
+	if len(resp.Tests) < len(job.Tests) {
+		fmt.Printf("⏰ Job %s melewati batas waktu %ds\n", job.ID, job.TimeoutSeconds)
+		finalStatus = "timed_out"
+	}
+
+	var summary strings.Builder
+	for _, c := range report.Cases {
+		fmt.Fprintf(&summary, "test %d: %s (%d ms, %d KB)\n", c.Index, c.Verdict, c.TimeMs, c.MemoryKB)
+	}
+	fields := map[string]interface{}{
+		"status":      finalStatus,
+		"worker_addr": workerAddr,
+		"result":      summary.String(),
+		"duration_ms": durationMs,
+	}
+	if resp.Compile != nil {
+		fields["compile_output"] = resp.Compile.Output
+		fields["compile_ms"] = resp.Compile.DurationMs
+	}
+	if data, err := json.Marshal(report); err != nil {
+		fmt.Printf("⚠️ Gagal encode hasil test job %s: %v\n", job.ID, err)
+	} else {
+		fields["judge"] = string(data)
+	}
+	d.finish(ctx, job, fields)
+}
+
+func testInputs(tests []judge.TestCase) []*pb.TestInput {
+	inputs := make([]*pb.TestInput, 0, len(tests))
+	for _, t := range tests {
+		inputs = append(inputs, &pb.TestInput{
+			Input:       t.Input,
+			TimeLimitMs: int32(t.TimeLimit().Milliseconds()),
+		})
+	}
+	return inputs
+}
+
+func (d *Dispatcher) takeOver(existing *database.Job) bool {
+	stale := time.Now().Add(-d.queue.VisibilityTimeout() * 3 / 4)
+	res := d.db.Model(&database.Job{}).
+		Where("id = ? AND status = ? AND updated_at < ?", existing.ID, "running", stale).
+		Update("updated_at", time.Now())
+	return res.RowsAffected > 0
+}
+
+func isLost(err error) bool {
+	return errors.Is(err, proxy.ErrWorkerGone) || errors.Is(err, proxy.ErrUnknownPlacement) || status.Code(err) == codes.NotFound
+}
+
+func (d *Dispatcher) handleFailure(ctx context.Context, job *queue.Job, policy queue.RetryPolicy, attempts int, err error) {
+	fmt.Printf("❌ Job %s Gagal (attempt %d/%d): %v\n", job.ID, attempts, policy.MaxAttempts, err)
+	resultLog := fmt.Sprintf("Error executing job: %v", err)
+
+	if IsRetryable(policy, err) && attempts < policy.MaxAttempts {
+		delay := Backoff(policy, attempts)
+		if qErr := d.queue.Retry(ctx, job, delay); qErr != nil {
+			fmt.Printf("⚠️ Gagal jadwalkan retry job %s: %v\n", job.ID, qErr)
+			return
+		}
+		d.db.Model(&database.Job{}).Where("id = ? AND status <> ?", job.ID, "cancelled").Updates(map[string]interface{}{
+			"status":     "retrying",
+			"result":     resultLog,
+			"updated_at": time.Now(),
+		})
+		jobsRetried.Inc()
+		fmt.Printf("🔁 Job %s di-retry dalam %s\n", job.ID, delay.Round(time.Millisecond))
+		return
+	}
+
+	if !d.updateResult(job.ID, map[string]interface{}{"status": "failed", "result": resultLog}) {
+		d.queue.Ack(ctx, job)
+		jobsProcessed.WithLabelValues("cancelled").Inc()
+		return
+	}
+	if qErr := d.queue.DeadLetter(ctx, job, err.Error()); qErr != nil {
+		fmt.Printf("⚠️ Gagal kirim job %s ke dead-letter queue: %v\n", job.ID, qErr)
+	} else {
+		jobsDeadLettered.Inc()
+	}
+	jobsProcessed.WithLabelValues("failed").Inc()
+	fmt.Printf("☠️ Job %s masuk dead-letter queue\n", job.ID)
+}
+
+func (d *Dispatcher) finish(ctx context.Context, job *queue.Job, fields map[string]interface{}) {
+	finalStatus := fields["status"].(string)
+	if !d.updateResult(job.ID, fields) {
+		finalStatus = "cancelled"
+	}
+	if err := d.queue.Ack(ctx, job); err != nil {
+		fmt.Printf("⚠️ Gagal ack job %s: %v\n", job.ID, err)
+	}
+
+	jobsProcessed.WithLabelValues(finalStatus).Inc()
+
+	fmt.Printf("✅ Job %s Selesai. Status: %s\n", job.ID, finalStatus)
+}
+
+func (d *Dispatcher) updateResult(jobID string, fields map[string]interface{}) bool {
+	fields["updated_at"] = time.Now()
+	res := d.db.Model(&database.Job{}).Where("id = ? AND status <> ?", jobID, "cancelled").Updates(fields)
+	return res.RowsAffected > 0
+}
+
+func waitTimeout(job *queue.Job) time.Duration {
+	if job.TimeoutSeconds <= 0 {
+		return defaultWaitTimeout
+	}
+	return time.Duration(job.TimeoutSeconds)*time.Second + waitGrace
+}
+
+func (d *Dispatcher) watchCancel(ctx context.Context, jobID string, cancel context.CancelFunc) {
+	ticker := time.NewTicker(cancelPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			var job database.Job
+			if err := d.db.Select("status").First(&job, "id = ?", jobID).Error; err == nil && job.Status == "cancelled" {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) extendLease(ctx context.Context, job *queue.Job, stop <-chan struct{}) {
+	ticker := time.NewTicker(d.queue.VisibilityTimeout() / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := d.queue.Extend(ctx, job); err != nil {
+				fmt.Printf("⚠️ Gagal extend lease job %s: %v\n", job.ID, err)
+			}
+			d.db.Model(&database.Job{}).Where("id = ? AND status = ?", job.ID, "running").Update("updated_at", time.Now())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const slotPollInterval = 500 * time.Millisecond
+
+type pool struct {
+	mu       sync.Mutex
+	inFlight int
+	size     func() int
+	released chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newPool(size func() int) *pool {
+	return &pool{
+		size:     size,
+		released: make(chan struct{}, 1),
+	}
+}
+
+func (p *pool) tryAcquire() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	size := p.size()
+	poolSize.Set(float64(size))
+	if p.inFlight >= size {
+		return false
+	}
+	p.inFlight++
+	p.wg.Add(1)
+	jobsInFlight.Set(float64(p.inFlight))
+	return true
+}
+
+func (p *pool) acquire(ctx context.Context) bool {
+	saturated := false
+	for {
+		if p.tryAcquire() {
+			return true
+		}
+		if !saturated {
+			saturated = true
+			dispatchSaturated.Inc()
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-p.released:
+		case <-time.After(slotPollInterval):
+		}
+	}
+}
+
+func (p *pool) release() {
+	p.mu.Lock()
+	p.inFlight--
+	jobsInFlight.Set(float64(p.inFlight))
+	p.mu.Unlock()
+	p.wg.Done()
+
+	select {
+	case p.released <- struct{}{}:
+	default:
+	}
+}
+
+func (p *pool) wait() {
+	p.wg.Wait()
+}
@@ -0,0 +1,64 @@
+package dispatcher
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/JullMol/nebula/internal/platform/queue"
+)
+
+func MergePolicy(p, defaults queue.RetryPolicy) queue.RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaults.Multiplier
+	}
+	if len(p.RetryableCodes) == 0 {
+		p.RetryableCodes = defaults.RetryableCodes
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 1
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 1
+	}
+	return p
+}
+
+func Backoff(p queue.RetryPolicy, attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	jitter := d * 0.2 * rand.Float64()
+	return time.Duration(d + jitter)
+}
+
+func IsRetryable(p queue.RetryPolicy, err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, name := range p.RetryableCodes {
+		var c codes.Code
+		if err := c.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+			continue
+		}
+		if c == st.Code() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,337 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/orchestrator/scheduler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrUnknownPlacement = errors.New("placement container tidak diketahui")
+	ErrWorkerGone       = errors.New("worker pemilik container sudah tidak terdaftar")
+)
+
+const (
+	maxStartResponseBytes = 64 * 1024 * 1024
+	imagePullTimeout      = 10 * time.Minute
+)
+
+type WorkerProvider interface {
+	Workers() []string
+}
+
+type ProxyService struct {
+	scheduler *scheduler.Scheduler
+	workers   WorkerProvider
+	health    *scheduler.HealthTracker
+	load      *scheduler.LoadTracker
+
+	mu         sync.RWMutex
+	placements map[string]string
+	placeMu    sync.Mutex
+}
+
+func NewProxyService(sched *scheduler.Scheduler, workers WorkerProvider, health *scheduler.HealthTracker, load *scheduler.LoadTracker) *ProxyService {
+	return &ProxyService{
+		scheduler:  sched,
+		workers:    workers,
+		health:     health,
+		load:       load,
+		placements: make(map[string]string),
+	}
+}
+
+func (s *ProxyService) Track(containerID, workerAddress string) {
+	if containerID == "" || workerAddress == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.placements[containerID]; !ok {
+		s.load.Inc(workerAddress)
+	}
+	s.placements[containerID] = workerAddress
+}
+
+func (s *ProxyService) adopt(containerID, workerAddress string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.placements[containerID] = workerAddress
+}
+
+func (s *ProxyService) Forget(containerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if workerAddress, ok := s.placements[containerID]; ok {
+		s.load.Dec(workerAddress)
+		delete(s.placements, containerID)
+	}
+}
+
+func (s *ProxyService) WorkerFor(containerID string) (string, error) {
+	s.mu.RLock()
+	workerAddress, ok := s.placements[containerID]
+	s.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownPlacement, containerID)
+	}
+
+	for _, w := range s.workers.Workers() {
+		if w == workerAddress {
+			return workerAddress, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (container %s)", ErrWorkerGone, workerAddress, containerID)
+}
+
+func (s *ProxyService) dialOwner(containerID string) (pb.WorkerServiceClient, *grpc.ClientConn, error) {
+	workerAddress, err := s.WorkerFor(containerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dial(workerAddress)
+}
+
+func dial(workerAddress string) (pb.WorkerServiceClient, *grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(workerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal connect ke worker %s: %w", workerAddress, err)
+	}
+	return pb.NewWorkerServiceClient(conn), conn, nil
+}
+
+func (s *ProxyService) Placeable(constraints scheduler.Constraints) []string {
+	return s.scheduler.Placeable(constraints, s.workers.Workers())
+}
+
+func (s *ProxyService) ForwardRunRequest(ctx context.Context, req *pb.StartContainerRequest, constraints scheduler.Constraints) (*pb.StartContainerResponse, string, error) {
+	need := scheduler.Requirements{Constraints: constraints, Image: req.Image}
+	if req.Limits != nil {
+		need.MemoryMB = req.Limits.MemoryMb
+		need.CPUs = req.Limits.Cpus
+	}
+
+	workers := s.workers.Workers()
+	tried := make(map[string]bool, len(workers))
+	var lastErr error
+	var workerAddress string
+	for {
+		decision, reservation := s.reserve(need, untried(workers, tried))
+		if !decision.Placed() {
+			if lastErr == nil {
+				lastErr = rejection(decision.Reason)
+			}
+			break
+		}
+		workerAddress = decision.Worker
+		tried[workerAddress] = true
+		fmt.Printf("🔀 [Proxy] Forwarding to: %s\n", workerAddress)
+
+		s.health.Acquire(workerAddress)
+		resp, err := s.startOn(ctx, workerAddress, req)
+		if err == nil {
+			s.health.ReportSuccess(workerAddress)
+			if resp.ContainerId != "" {
+				reservation.Started()
+				s.adopt(resp.ContainerId, workerAddress)
+			} else {
+				reservation.Cancel()
+			}
+			return resp, workerAddress, nil
+		}
+
+		reservation.Cancel()
+		if !isWorkerFailure(err) {
+			s.health.ReportSuccess(workerAddress)
+			return nil, workerAddress, err
+		}
+
+		s.health.ReportFailure(workerAddress, err)
+		lastErr = err
+		log.Printf("⚠️ Worker %s gagal (%v), failover ke worker lain", workerAddress, err)
+	}
+	return nil, workerAddress, lastErr
+}
+
+func (s *ProxyService) reserve(need scheduler.Requirements, workers []string) (scheduler.Decision, *scheduler.Reservation) {
+	s.placeMu.Lock()
+	defer s.placeMu.Unlock()
+
+	decision := s.scheduler.Schedule(need, workers)
+	if !decision.Placed() {
+		return decision, nil
+	}
+	return decision, s.load.Reserve(decision.Worker, s.scheduler.Requirements(decision.Worker, need))
+}
+
+func rejection(reason error) error {
+	switch {
+	case errors.Is(reason, scheduler.ErrNoMatchingWorker):
+		return status.Error(codes.FailedPrecondition, reason.Error())
+	case errors.Is(reason, scheduler.ErrNoCapacity):
+		return status.Error(codes.ResourceExhausted, reason.Error())
+	}
+	return status.Error(codes.Unavailable, reason.Error())
+}
+
+func (s *ProxyService) startOn(ctx context.Context, workerAddress string, req *pb.StartContainerRequest) (*pb.StartContainerResponse, error) {
+	conn, err := grpc.NewClient(workerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("❌ Gagal connect ke worker %s: %v", workerAddress, err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer conn.Close()
+
+	client := pb.NewWorkerServiceClient(conn)
+
+	timeout := 30*time.Second + time.Duration(req.Runtime.GetCompileTimeoutSeconds())*time.Second
+	if !s.scheduler.Cached(workerAddress, req.Image) {
+		timeout += imagePullTimeout
+	}
+	if len(req.Tests) > 0 {
+		timeout += time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return client.StartContainer(ctx, req, grpc.MaxCallRecvMsgSize(maxStartResponseBytes))
+}
+
+func untried(candidates []string, tried map[string]bool) []string {
+	left := make([]string, 0, len(candidates))
+	for _, w := range candidates {
+		if !tried[w] {
+			left = append(left, w)
+		}
+	}
+	return left
+}
+
+func isWorkerFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func (s *ProxyService) ForwardWaitRequest(ctx context.Context, containerID string, timeout time.Duration) (*pb.WaitContainerResponse, error) {
+	client, conn, err := s.dialOwner(containerID)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return client.WaitContainer(ctx, &pb.WaitContainerRequest{ContainerId: containerID})
+}
+
+func (s *ProxyService) ForwardLogRequest(ctx context.Context, containerID string) (*pb.GetLogsResponse, error) {
+	client, conn, err := s.dialOwner(containerID)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return client.GetLogs(ctx, &pb.GetLogsRequest{ContainerId: containerID})
+}
+
+func (s *ProxyService) ForwardRemoveRequest(ctx context.Context, containerID string) error {
+	workerAddress, err := s.WorkerFor(containerID)
+	if err != nil {
+		return err
+	}
+	return s.RemoveContainer(ctx, workerAddress, containerID)
+}
+
+func (s *ProxyService) ForwardStopRequest(ctx context.Context, containerID string) error {
+	workerAddress, err := s.WorkerFor(containerID)
+	if err != nil {
+		return err
+	}
+	return s.StopOn(ctx, workerAddress, containerID)
+}
+
+func (s *ProxyService) StopOn(ctx context.Context, workerAddress, containerID string) error {
+	client, conn, err := dial(workerAddress)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err = client.StopContainer(ctx, &pb.StopContainerRequest{ContainerId: containerID})
+	return err
+}
+
+func (s *ProxyService) ListContainers(ctx context.Context, workerAddress string) ([]*pb.ContainerInfo, error) {
+	conn, err := grpc.NewClient(workerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("gagal connect ke worker %s: %w", workerAddress, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewWorkerServiceClient(conn).ListContainers(ctx, &pb.ListContainersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Containers, nil
+}
+
+func (s *ProxyService) RemoveContainer(ctx context.Context, workerAddress, containerID string) error {
+	conn, err := grpc.NewClient(workerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("gagal connect ke worker %s: %w", workerAddress, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err = pb.NewWorkerServiceClient(conn).RemoveContainer(ctx, &pb.RemoveContainerRequest{ContainerId: containerID, Force: true})
+	return err
+}
+
+func (s *ProxyService) StreamLogsFrom(ctx context.Context, workerAddress, containerID string, onChunk func(*pb.LogChunk) error) error {
+	client, conn, err := dial(workerAddress)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := client.StreamLogs(ctx, &pb.StreamLogsRequest{ContainerId: containerID})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := onChunk(chunk); err != nil {
+			return err
+		}
+	}
+}
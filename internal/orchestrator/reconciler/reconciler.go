@@ -0,0 +1,261 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/orchestrator/dispatcher"
+	"github.com/JullMol/nebula/internal/orchestrator/proxy"
+	"github.com/JullMol/nebula/internal/platform/database"
+	"github.com/JullMol/nebula/internal/platform/leader"
+	"github.com/JullMol/nebula/internal/platform/queue"
+)
+
+const (
+	DefaultInterval   = time.Minute
+	DefaultStaleAfter = 5 * time.Minute
+	strayGrace        = 2 * time.Minute
+)
+
+var reconcileActions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "nebula_reconciler_actions_total",
+	Help: "Total tindakan reconciler berdasarkan jenis",
+}, []string{"action"})
+
+type WorkerProvider interface {
+	Workers() []string
+}
+
+type located struct {
+	worker string
+	info   *pb.ContainerInfo
+}
+
+type Reconciler struct {
+	db          *gorm.DB
+	queue       queue.QueueSystem
+	proxy       *proxy.ProxyService
+	dispatcher  *dispatcher.Dispatcher
+	workers     WorkerProvider
+	elector     *leader.Elector
+	retryPolicy queue.RetryPolicy
+	interval    time.Duration
+	staleAfter  time.Duration
+}
+
+func NewReconciler(db *gorm.DB, q queue.QueueSystem, proxySvc *proxy.ProxyService, disp *dispatcher.Dispatcher, workers WorkerProvider, elector *leader.Elector, retryPolicy queue.RetryPolicy, interval, staleAfter time.Duration) *Reconciler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	return &Reconciler{
+		db:          db,
+		queue:       q,
+		proxy:       proxySvc,
+		dispatcher:  disp,
+		workers:     workers,
+		elector:     elector,
+		retryPolicy: retryPolicy,
+		interval:    interval,
+		staleAfter:  staleAfter,
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context, token int64) {
+	fmt.Printf("🧹 [Reconciler] Aktif sebagai leader (token %d)\n", token)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.reconcile(ctx, token)
+		select {
+		case <-ctx.Done():
+			fmt.Println("🧹 [Reconciler] Berhenti, leadership lepas")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, token int64) {
+	live, reachable := r.inventory(ctx)
+	if !r.fenced(ctx, token) {
+		return
+	}
+	r.recoverOrphans(ctx, token, live, reachable)
+	r.removeStrays(ctx, token, live)
+}
+
+func (r *Reconciler) inventory(ctx context.Context) (map[string]located, map[string]bool) {
+	live := make(map[string]located)
+	reachable := make(map[string]bool)
+	for _, addr := range r.workers.Workers() {
+		containers, err := r.proxy.ListContainers(ctx, addr)
+		if err != nil {
+			fmt.Printf("⚠️ [Reconciler] Gagal list container di %s: %v\n", addr, err)
+			continue
+		}
+		reachable[addr] = true
+		for _, ctr := range containers {
+			live[ctr.ContainerId] = located{worker: addr, info: ctr}
+		}
+	}
+	return live, reachable
+}
+
+func (r *Reconciler) recoverOrphans(ctx context.Context, token int64, live map[string]located, reachable map[string]bool) {
+	var jobs []database.Job
+	stale := time.Now().Add(-r.staleAfter)
+	if err := r.db.Where("status = ? AND updated_at < ?", "running", stale).Find(&jobs).Error; err != nil {
+		fmt.Printf("⚠️ [Reconciler] Gagal baca job running: %v\n", err)
+		return
+	}
+
+	registered := make(map[string]bool)
+	for _, addr := range r.workers.Workers() {
+		registered[addr] = true
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		if job.ContainerID != "" && registered[job.WorkerAddr] && !reachable[job.WorkerAddr] {
+			continue
+		}
+		if !r.fenced(ctx, token) || !r.claim(job, token, stale) {
+			continue
+		}
+
+		if _, ok := live[job.ContainerID]; ok && job.ContainerID != "" {
+			fmt.Printf("🔗 [Reconciler] Re-attach job %s ke container %s di %s\n", job.ID, job.ContainerID, job.WorkerAddr)
+			reconcileActions.WithLabelValues("reattached").Inc()
+			go r.dispatcher.Attach(context.Background(), job)
+			continue
+		}
+		r.requeue(ctx, job, token)
+	}
+}
+
+func (r *Reconciler) claim(job *database.Job, token int64, stale time.Time) bool {
+	res := r.db.Model(&database.Job{}).
+		Where("id = ? AND status = ? AND updated_at < ? AND leader_token <= ?", job.ID, "running", stale, token).
+		Updates(map[string]interface{}{
+			"leader_token": token,
+			"updated_at":   time.Now(),
+		})
+	return res.RowsAffected > 0
+}
+
+func (r *Reconciler) requeue(ctx context.Context, job *database.Job, token int64) {
+	var spec queue.Job
+	if job.Spec == "" || json.Unmarshal([]byte(job.Spec), &spec) != nil {
+		r.fail(ctx, job, token, nil, "container hilang dan spesifikasi job tidak tersimpan")
+		return
+	}
+
+	policy := dispatcher.MergePolicy(spec.Retry, r.retryPolicy)
+	if job.Attempts >= policy.MaxAttempts {
+		r.fail(ctx, job, token, &spec, fmt.Sprintf("container hilang setelah %d attempt", job.Attempts))
+		return
+	}
+
+	if err := r.queue.Remove(ctx, job.ID); err != nil && err != queue.ErrNotFound {
+		fmt.Printf("⚠️ [Reconciler] Gagal hapus job %s dari queue: %v\n", job.ID, err)
+		return
+	}
+	res := r.db.Model(&database.Job{}).Where("id = ? AND status = ? AND leader_token <= ?", job.ID, "running", token).Updates(map[string]interface{}{
+		"status":       "queued",
+		"container_id": "",
+		"worker_addr":  "",
+		"leader_token": token,
+		"updated_at":   time.Now(),
+	})
+	if res.RowsAffected == 0 {
+		return
+	}
+	if err := r.queue.Enqueue(ctx, spec); err != nil {
+		fmt.Printf("⚠️ [Reconciler] Gagal enqueue ulang job %s: %v\n", job.ID, err)
+		return
+	}
+	reconcileActions.WithLabelValues("requeued").Inc()
+	fmt.Printf("🔁 [Reconciler] Job %s kehilangan container, masuk queue lagi\n", job.ID)
+}
+
+func (r *Reconciler) fail(ctx context.Context, job *database.Job, token int64, spec *queue.Job, reason string) {
+	res := r.db.Model(&database.Job{}).Where("id = ? AND status = ? AND leader_token <= ?", job.ID, "running", token).Updates(map[string]interface{}{
+		"status":       "failed",
+		"result":       "Error executing job: " + reason,
+		"leader_token": token,
+		"updated_at":   time.Now(),
+	})
+	if res.RowsAffected == 0 {
+		return
+	}
+	if spec != nil {
+		r.queue.Remove(ctx, job.ID)
+		if err := r.queue.DeadLetter(ctx, spec, reason); err != nil {
+			fmt.Printf("⚠️ [Reconciler] Gagal kirim job %s ke dead-letter queue: %v\n", job.ID, err)
+		}
+	}
+	reconcileActions.WithLabelValues("failed").Inc()
+	fmt.Printf("☠️ [Reconciler] Job %s gagal: %s\n", job.ID, reason)
+}
+
+func (r *Reconciler) removeStrays(ctx context.Context, token int64, live map[string]located) {
+	if len(live) == 0 {
+		return
+	}
+
+	jobIDs := make([]string, 0, len(live))
+	for _, loc := range live {
+		if loc.info.JobId != "" {
+			jobIDs = append(jobIDs, loc.info.JobId)
+		}
+	}
+	var jobs []database.Job
+	if err := r.db.Where("id IN ?", jobIDs).Find(&jobs).Error; err != nil {
+		fmt.Printf("⚠️ [Reconciler] Gagal baca job untuk container: %v\n", err)
+		return
+	}
+	byID := make(map[string]database.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+
+	for containerID, loc := range live {
+		if time.Since(loc.info.CreatedAt.AsTime()) < strayGrace {
+			continue
+		}
+		job, ok := byID[loc.info.JobId]
+		if ok && job.ContainerID == "" && !database.IsTerminalStatus(job.Status) {
+			continue
+		}
+		stray := !ok || job.ContainerID != containerID ||
+			(database.IsTerminalStatus(job.Status) && loc.info.State == "running")
+		if !stray || !r.fenced(ctx, token) {
+			continue
+		}
+
+		if err := r.proxy.RemoveContainer(ctx, loc.worker, containerID); err != nil {
+			fmt.Printf("⚠️ [Reconciler] Gagal hapus container liar %s di %s: %v\n", containerID, loc.worker, err)
+			continue
+		}
+		reconcileActions.WithLabelValues("stray_removed").Inc()
+		fmt.Printf("🗑️ [Reconciler] Container liar %s (job %s) dihapus dari %s\n", containerID, loc.info.JobId, loc.worker)
+	}
+}
+
+func (r *Reconciler) fenced(ctx context.Context, token int64) bool {
+	if err := r.elector.CheckToken(ctx, token); err != nil {
+		fmt.Printf("⚠️ [Reconciler] Lewati reconcile: %v\n", err)
+		return false
+	}
+	return true
+}
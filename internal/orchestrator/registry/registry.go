@@ -0,0 +1,177 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const DefaultHeartbeatTTL = 15 * time.Second
+
+type WorkerInfo struct {
+	Name            string            `json:"name"`
+	Address         string            `json:"address"`
+	Capacity        int               `json:"capacity"`
+	Labels          map[string]string `json:"labels"`
+	Running         int               `json:"running"`
+	Weight          int               `json:"weight"`
+	TotalMemoryMB   int64             `json:"total_memory_mb"`
+	TotalCPUs       float64           `json:"total_cpus"`
+	FreeMemoryMB    int64             `json:"free_memory_mb"`
+	FreeCPUs        float64           `json:"free_cpus"`
+	DefaultMemoryMB int64             `json:"default_memory_mb"`
+	DefaultCPUs     float64           `json:"default_cpus"`
+	Images          []string          `json:"images"`
+	Static          bool              `json:"static"`
+	RegisteredAt    time.Time         `json:"registered_at"`
+	LastHeartbeat   time.Time         `json:"last_heartbeat"`
+}
+
+type Registry struct {
+	mu      sync.RWMutex
+	workers map[string]*WorkerInfo
+	ttl     time.Duration
+}
+
+func NewRegistry(staticWorkers []string, ttl time.Duration) *Registry {
+	if ttl <= 0 {
+		ttl = DefaultHeartbeatTTL
+	}
+	r := &Registry{
+		workers: make(map[string]*WorkerInfo),
+		ttl:     ttl,
+	}
+	now := time.Now()
+	for _, addr := range staticWorkers {
+		r.workers[addr] = &WorkerInfo{
+			Name:          addr,
+			Address:       addr,
+			Static:        true,
+			RegisteredAt:  now,
+			LastHeartbeat: now,
+		}
+	}
+	return r
+}
+
+func (r *Registry) Register(info WorkerInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	info.RegisteredAt = now
+	info.LastHeartbeat = now
+	info.FreeMemoryMB = info.TotalMemoryMB
+	info.FreeCPUs = info.TotalCPUs
+	if existing, ok := r.workers[info.Address]; ok && existing.Static {
+		info.Static = true
+	}
+	r.workers[info.Address] = &info
+	fmt.Printf("🛰️ [Registry] Worker %s terdaftar di %s (capacity=%d)\n", info.Name, info.Address, info.Capacity)
+}
+
+type Usage struct {
+	Running      int
+	FreeMemoryMB int64
+	FreeCPUs     float64
+	Images       []string
+}
+
+func (r *Registry) Heartbeat(address string, usage Usage) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.workers[address]
+	if !ok {
+		return false
+	}
+	w.LastHeartbeat = time.Now()
+	w.Running = usage.Running
+	w.FreeMemoryMB = usage.FreeMemoryMB
+	w.FreeCPUs = usage.FreeCPUs
+	w.Images = usage.Images
+	return true
+}
+
+func (r *Registry) Info(address string) (WorkerInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.workers[address]
+	if !ok {
+		return WorkerInfo{}, false
+	}
+	return *w, true
+}
+
+func (r *Registry) Workers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	addrs := make([]string, 0, len(r.workers))
+	for addr := range r.workers {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+func (r *Registry) Snapshot() []WorkerInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]WorkerInfo, 0, len(r.workers))
+	for _, w := range r.workers {
+		list = append(list, *w)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Address < list[j].Address })
+	return list
+}
+
+func (r *Registry) TotalCapacity() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	total := 0
+	for _, w := range r.workers {
+		if w.Capacity > 0 {
+			total += w.Capacity
+		} else {
+			total++
+		}
+	}
+	return total
+}
+
+func (r *Registry) HeartbeatInterval() time.Duration {
+	return r.ttl / 3
+}
+
+func (r *Registry) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.HeartbeatInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.evictExpired()
+		}
+	}
+}
+
+func (r *Registry) evictExpired() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadline := time.Now().Add(-r.ttl)
+	for addr, w := range r.workers {
+		if w.Static || w.LastHeartbeat.After(deadline) {
+			continue
+		}
+		delete(r.workers, addr)
+		fmt.Printf("💀 [Registry] Worker %s (%s) tidak kirim heartbeat, dikeluarkan\n", w.Name, addr)
+	}
+}
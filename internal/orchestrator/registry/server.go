@@ -0,0 +1,50 @@
+package registry
+
+import (
+	"context"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	pb.UnimplementedControlPlaneServer
+	registry *Registry
+}
+
+func NewServer(registry *Registry) *Server {
+	return &Server{registry: registry}
+}
+
+func (s *Server) RegisterWorker(ctx context.Context, req *pb.RegisterWorkerRequest) (*pb.RegisterWorkerResponse, error) {
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "address wajib diisi")
+	}
+
+	s.registry.Register(WorkerInfo{
+		Name:            req.Name,
+		Address:         req.Address,
+		Capacity:        int(req.Capacity),
+		Labels:          req.Labels,
+		Weight:          int(req.Weight),
+		TotalMemoryMB:   req.TotalMemoryMb,
+		TotalCPUs:       req.TotalCpus,
+		DefaultMemoryMB: req.DefaultLimits.GetMemoryMb(),
+		DefaultCPUs:     req.DefaultLimits.GetCpus(),
+	})
+
+	return &pb.RegisterWorkerResponse{
+		HeartbeatIntervalSeconds: int32(s.registry.HeartbeatInterval().Seconds()),
+	}, nil
+}
+
+func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	known := s.registry.Heartbeat(req.Address, Usage{
+		Running:      int(req.RunningContainers),
+		FreeMemoryMB: req.FreeMemoryMb,
+		FreeCPUs:     req.FreeCpus,
+		Images:       req.Images,
+	})
+	return &pb.HeartbeatResponse{Known: known}, nil
+}
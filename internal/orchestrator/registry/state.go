@@ -0,0 +1,31 @@
+package registry
+
+import "github.com/JullMol/nebula/internal/orchestrator/scheduler"
+
+func NewStateSource(r *Registry, load *scheduler.LoadTracker) scheduler.StateSource {
+	return scheduler.StateFunc(func(addr string) scheduler.WorkerState {
+		st := scheduler.WorkerState{
+			Address:     addr,
+			Outstanding: load.Outstanding(addr),
+		}
+		info, ok := r.Info(addr)
+		if !ok {
+			return st
+		}
+		if info.Running > st.Outstanding {
+			st.Outstanding = info.Running
+		}
+		st.Capacity = info.Capacity
+		st.Weight = info.Weight
+		st.TotalMemoryMB = info.TotalMemoryMB
+		st.TotalCPUs = info.TotalCPUs
+		pendingMemoryMB, pendingCPUs := load.Pending(addr, info.LastHeartbeat)
+		st.FreeMemoryMB = max(info.FreeMemoryMB-pendingMemoryMB, 0)
+		st.FreeCPUs = max(info.FreeCPUs-pendingCPUs, 0)
+		st.DefaultMemoryMB = info.DefaultMemoryMB
+		st.DefaultCPUs = info.DefaultCPUs
+		st.Images = info.Images
+		st.Labels = info.Labels
+		return st
+	})
+}
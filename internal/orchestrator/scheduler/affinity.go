@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const virtualNodes = 64
+
+type ImageAffinity struct {
+	rr RoundRobin
+}
+
+func NewImageAffinity() *ImageAffinity {
+	return &ImageAffinity{}
+}
+
+func (a *ImageAffinity) Name() string {
+	return PolicyImageAffinity
+}
+
+func (a *ImageAffinity) Place(req Requirements, workers []WorkerState) Decision {
+	if req.Image == "" {
+		return Place(a.rr.next(leastLoaded(workers, func(WorkerState) bool { return true })))
+	}
+
+	warm := leastLoaded(workers, func(st WorkerState) bool { return st.HasImage(req.Image) })
+	if len(warm) > 0 {
+		return Place(a.rr.next(warm))
+	}
+
+	var open []string
+	for _, st := range workers {
+		if !st.Full() {
+			open = append(open, st.Address)
+		}
+	}
+	return Place(hashRing(open, NormalizeImage(req.Image)))
+}
+
+func hashRing(workers []string, key string) string {
+	if len(workers) == 0 {
+		return ""
+	}
+
+	type point struct {
+		hash uint32
+		addr string
+	}
+	ring := make([]point, 0, len(workers)*virtualNodes)
+	for _, addr := range workers {
+		for i := 0; i < virtualNodes; i++ {
+			ring = append(ring, point{crc32.ChecksumIEEE([]byte(addr + "#" + strconv.Itoa(i))), addr})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool { return ring[i].hash < ring[j].hash })
+
+	h := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(ring), func(i int) bool { return ring[i].hash >= h })
+	if i == len(ring) {
+		i = 0
+	}
+	return ring[i].addr
+}
+
+func NormalizeImage(name string) string {
+	if strings.Contains(name, "@") {
+		return name
+	}
+	if strings.LastIndex(name, ":") <= strings.LastIndex(name, "/") {
+		name += ":latest"
+	}
+	return strings.TrimPrefix(name, "docker.io/library/")
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+type Constraints struct {
+	NodeSelector map[string]string
+	AntiAffinity map[string]string
+}
+
+func (c Constraints) Empty() bool {
+	return len(c.NodeSelector) == 0 && len(c.AntiAffinity) == 0
+}
+
+func (c Constraints) Matches(labels map[string]string) bool {
+	for key, want := range c.NodeSelector {
+		if got, ok := labels[key]; !ok || got != want {
+			return false
+		}
+	}
+	for key, avoid := range c.AntiAffinity {
+		got, ok := labels[key]
+		if !ok {
+			continue
+		}
+		if avoid == "" || got == avoid {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	DefaultFailureThreshold = 3
+	DefaultOpenDuration     = 30 * time.Second
+	DefaultCheckInterval    = 5 * time.Second
+	DefaultCheckTimeout     = 2 * time.Second
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+type WorkerHealth struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            time.Time    `json:"opened_at,omitempty"`
+	LastError           string       `json:"last_error,omitempty"`
+}
+
+type HealthTracker struct {
+	mu               sync.Mutex
+	workers          map[string]*WorkerHealth
+	failureThreshold int
+	openDuration     time.Duration
+	trialInFlight    map[string]bool
+}
+
+func NewHealthTracker(failureThreshold int, openDuration time.Duration) *HealthTracker {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultFailureThreshold
+	}
+	if openDuration <= 0 {
+		openDuration = DefaultOpenDuration
+	}
+	return &HealthTracker{
+		workers:          make(map[string]*WorkerHealth),
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		trialInFlight:    make(map[string]bool),
+	}
+}
+
+func (h *HealthTracker) get(addr string) *WorkerHealth {
+	w, ok := h.workers[addr]
+	if !ok {
+		w = &WorkerHealth{State: CircuitClosed}
+		h.workers[addr] = w
+	}
+	return w
+}
+
+func (h *HealthTracker) Available(workers []string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	available := make([]string, 0, len(workers))
+	for _, addr := range workers {
+		w := h.get(addr)
+		if w.State == CircuitOpen && now.Sub(w.OpenedAt) >= h.openDuration {
+			w.State = CircuitHalfOpen
+		}
+		switch w.State {
+		case CircuitClosed:
+			available = append(available, addr)
+		case CircuitHalfOpen:
+			if !h.trialInFlight[addr] {
+				available = append(available, addr)
+			}
+		}
+	}
+	return available
+}
+
+func (h *HealthTracker) State(addr string) CircuitState {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.get(addr).State
+}
+
+func (h *HealthTracker) Acquire(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.get(addr).State == CircuitHalfOpen {
+		h.trialInFlight[addr] = true
+	}
+}
+
+func (h *HealthTracker) ReportSuccess(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.close(addr, h.get(addr))
+}
+
+func (h *HealthTracker) close(addr string, w *WorkerHealth) {
+	if w.State != CircuitClosed {
+		fmt.Printf("💚 [Health] Circuit %s tertutup lagi\n", addr)
+	}
+	w.State = CircuitClosed
+	w.ConsecutiveFailures = 0
+	w.LastError = ""
+	delete(h.trialInFlight, addr)
+}
+
+func (h *HealthTracker) reportCheckSuccess(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w := h.get(addr)
+	switch w.State {
+	case CircuitClosed:
+		w.ConsecutiveFailures = 0
+	case CircuitOpen:
+		if time.Since(w.OpenedAt) >= h.openDuration {
+			h.close(addr, w)
+		}
+	case CircuitHalfOpen:
+		h.close(addr, w)
+	}
+}
+
+func (h *HealthTracker) ReportFailure(addr string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w := h.get(addr)
+	w.ConsecutiveFailures++
+	if err != nil {
+		w.LastError = err.Error()
+	}
+	delete(h.trialInFlight, addr)
+
+	if w.State == CircuitHalfOpen || w.ConsecutiveFailures >= h.failureThreshold {
+		if w.State != CircuitOpen {
+			fmt.Printf("🔴 [Health] Circuit %s terbuka setelah %d kegagalan: %v\n", addr, w.ConsecutiveFailures, err)
+		}
+		w.State = CircuitOpen
+		w.OpenedAt = time.Now()
+	}
+}
+
+func (h *HealthTracker) Snapshot() map[string]WorkerHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make(map[string]WorkerHealth, len(h.workers))
+	for addr, w := range h.workers {
+		out[addr] = *w
+	}
+	return out
+}
+
+func (h *HealthTracker) RunChecks(ctx context.Context, interval time.Duration, workers func() []string) {
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := workers()
+			h.prune(current)
+			for _, addr := range current {
+				if err := checkWorker(ctx, addr); err != nil {
+					h.ReportFailure(addr, err)
+				} else {
+					h.reportCheckSuccess(addr)
+				}
+			}
+		}
+	}
+}
+
+func (h *HealthTracker) prune(current []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keep := make(map[string]bool, len(current))
+	for _, addr := range current {
+		keep[addr] = true
+	}
+	for addr := range h.workers {
+		if !keep[addr] {
+			delete(h.workers, addr)
+			delete(h.trialInFlight, addr)
+		}
+	}
+}
+
+func checkWorker(ctx context.Context, addr string) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultCheckTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("worker %s status %s", addr, resp.Status)
+	}
+	return nil
+}
@@ -1,5 +1,39 @@
 package scheduler
 
-type SchedulerPolicy interface {
-	NextWorker(availableWorkers []string) string
-}
\ No newline at end of file
+import "errors"
+
+var (
+	ErrNoWorkers        = errors.New("belum ada worker yang terdaftar")
+	ErrNoMatchingWorker = errors.New("tidak ada worker yang cocok dengan node_selector/anti_affinity")
+	ErrNoHealthyWorker  = errors.New("tidak ada worker sehat yang tersedia")
+	ErrNoCapacity       = errors.New("tidak ada worker dengan kapasitas cukup")
+)
+
+type Requirements struct {
+	Constraints
+	Image    string
+	MemoryMB int64
+	CPUs     float64
+}
+
+type Decision struct {
+	Worker string
+	Reason error
+}
+
+func Place(worker string) Decision {
+	return Decision{Worker: worker}
+}
+
+func Reject(reason error) Decision {
+	return Decision{Reason: reason}
+}
+
+func (d Decision) Placed() bool {
+	return d.Worker != ""
+}
+
+type Policy interface {
+	Name() string
+	Place(req Requirements, workers []WorkerState) Decision
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"math"
+	"sync"
+)
+
+type LeastLoaded struct {
+	rr RoundRobin
+}
+
+func NewLeastLoaded() *LeastLoaded {
+	return &LeastLoaded{}
+}
+
+func (l *LeastLoaded) Name() string {
+	return PolicyLeastLoaded
+}
+
+func (l *LeastLoaded) Place(req Requirements, workers []WorkerState) Decision {
+	return Place(l.rr.next(leastLoaded(workers, func(WorkerState) bool { return true })))
+}
+
+func leastLoaded(workers []WorkerState, keep func(WorkerState) bool) []WorkerState {
+	var best []WorkerState
+	bestLoad := math.MaxInt
+	for _, st := range workers {
+		if st.Full() || !keep(st) {
+			continue
+		}
+		switch {
+		case st.Outstanding < bestLoad:
+			bestLoad = st.Outstanding
+			best = []WorkerState{st}
+		case st.Outstanding == bestLoad:
+			best = append(best, st)
+		}
+	}
+	return best
+}
+
+type Weighted struct {
+	mu      sync.Mutex
+	current map[string]int
+}
+
+func NewWeighted() *Weighted {
+	return &Weighted{current: make(map[string]int)}
+}
+
+func (w *Weighted) Name() string {
+	return PolicyWeighted
+}
+
+func (w *Weighted) Place(req Requirements, workers []WorkerState) Decision {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	total := 0
+	best := ""
+	for _, st := range workers {
+		if st.Full() {
+			continue
+		}
+		weight := st.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+		w.current[st.Address] += weight
+		if best == "" || w.current[st.Address] > w.current[best] {
+			best = st.Address
+		}
+	}
+	if best != "" {
+		w.current[best] -= total
+	}
+	return Place(best)
+}
+
+type ResourceFit struct{}
+
+func NewResourceFit() *ResourceFit {
+	return &ResourceFit{}
+}
+
+func (r *ResourceFit) Name() string {
+	return PolicyResourceFit
+}
+
+func (r *ResourceFit) Place(req Requirements, workers []WorkerState) Decision {
+	best := ""
+	bestScore := -1.0
+	for _, st := range workers {
+		need := st.Resolve(req)
+		if st.Full() || !fits(st, need) {
+			continue
+		}
+		score := headroom(st, need)
+		if score > bestScore {
+			best = st.Address
+			bestScore = score
+		}
+	}
+	return Place(best)
+}
+
+func fits(st WorkerState, req Requirements) bool {
+	if st.TotalMemoryMB > 0 && st.FreeMemoryMB < req.MemoryMB {
+		return false
+	}
+	if st.TotalCPUs > 0 && st.FreeCPUs < req.CPUs {
+		return false
+	}
+	return true
+}
+
+func headroom(st WorkerState, req Requirements) float64 {
+	if st.TotalMemoryMB <= 0 || st.TotalCPUs <= 0 {
+		return 0
+	}
+	mem := float64(st.FreeMemoryMB-req.MemoryMB) / float64(st.TotalMemoryMB)
+	cpu := (st.FreeCPUs - req.CPUs) / st.TotalCPUs
+	return math.Min(mem, cpu)
+}
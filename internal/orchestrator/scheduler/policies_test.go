@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPoliciesUnevenLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		req     Requirements
+		workers []WorkerState
+		want    []string
+	}{
+		{
+			name:   "round_robin skips full workers",
+			policy: NewRoundRobin(),
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 1, Capacity: 4},
+				{Address: "b", Outstanding: 4, Capacity: 4},
+				{Address: "c", Outstanding: 0, Capacity: 4},
+			},
+			want: []string{"a", "c", "a", "c"},
+		},
+		{
+			name:   "round_robin rejects when every worker is full",
+			policy: NewRoundRobin(),
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 2, Capacity: 2},
+				{Address: "b", Outstanding: 3, Capacity: 3},
+			},
+			want: []string{"", ""},
+		},
+		{
+			name:   "least_loaded picks the idle worker",
+			policy: NewLeastLoaded(),
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 5, Capacity: 8},
+				{Address: "b", Outstanding: 0, Capacity: 8},
+				{Address: "c", Outstanding: 3, Capacity: 8},
+			},
+			want: []string{"b"},
+		},
+		{
+			name:   "least_loaded skips full workers",
+			policy: NewLeastLoaded(),
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 2, Capacity: 2},
+				{Address: "b", Outstanding: 6, Capacity: 10},
+			},
+			want: []string{"b", "b"},
+		},
+		{
+			name:   "least_loaded rotates between ties",
+			policy: NewLeastLoaded(),
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 1, Capacity: 4},
+				{Address: "b", Outstanding: 1, Capacity: 4},
+				{Address: "c", Outstanding: 3, Capacity: 4},
+			},
+			want: []string{"a", "b", "a"},
+		},
+		{
+			name:   "least_loaded rejects when every worker is full",
+			policy: NewLeastLoaded(),
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 2, Capacity: 2},
+				{Address: "b", Outstanding: 4, Capacity: 4},
+			},
+			want: []string{""},
+		},
+		{
+			name:   "weighted follows weights",
+			policy: NewWeighted(),
+			workers: []WorkerState{
+				{Address: "a", Weight: 2, Capacity: 8},
+				{Address: "b", Weight: 1, Capacity: 8},
+			},
+			want: []string{"a", "b", "a", "a", "b", "a"},
+		},
+		{
+			name:   "weighted skips full workers",
+			policy: NewWeighted(),
+			workers: []WorkerState{
+				{Address: "a", Weight: 5, Outstanding: 3, Capacity: 3},
+				{Address: "b", Weight: 1, Outstanding: 1, Capacity: 3},
+			},
+			want: []string{"b", "b"},
+		},
+		{
+			name:   "weighted rejects when every worker is full",
+			policy: NewWeighted(),
+			workers: []WorkerState{
+				{Address: "a", Weight: 2, Outstanding: 2, Capacity: 2},
+				{Address: "b", Weight: 1, Outstanding: 1, Capacity: 1},
+			},
+			want: []string{"", "", ""},
+		},
+		{
+			name:   "resource_fit picks the most headroom",
+			policy: NewResourceFit(),
+			req:    Requirements{MemoryMB: 512, CPUs: 1},
+			workers: []WorkerState{
+				{Address: "a", Capacity: 8, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 1024, FreeCPUs: 1},
+				{Address: "b", Capacity: 8, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 6144, FreeCPUs: 3},
+				{Address: "c", Capacity: 8, TotalMemoryMB: 16384, TotalCPUs: 8, FreeMemoryMB: 2048, FreeCPUs: 6},
+			},
+			want: []string{"b"},
+		},
+		{
+			name:   "resource_fit skips workers that cannot fit",
+			policy: NewResourceFit(),
+			req:    Requirements{MemoryMB: 2048, CPUs: 2},
+			workers: []WorkerState{
+				{Address: "a", Capacity: 8, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 8000, FreeCPUs: 1},
+				{Address: "b", Capacity: 8, TotalMemoryMB: 4096, TotalCPUs: 4, FreeMemoryMB: 2048, FreeCPUs: 2},
+			},
+			want: []string{"b"},
+		},
+		{
+			name:   "resource_fit skips full workers",
+			policy: NewResourceFit(),
+			req:    Requirements{MemoryMB: 256, CPUs: 0.5},
+			workers: []WorkerState{
+				{Address: "a", Outstanding: 4, Capacity: 4, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 8192, FreeCPUs: 4},
+				{Address: "b", Outstanding: 1, Capacity: 4, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 1024, FreeCPUs: 1},
+			},
+			want: []string{"b"},
+		},
+		{
+			name:   "resource_fit rejects when nothing fits",
+			policy: NewResourceFit(),
+			req:    Requirements{MemoryMB: 4096, CPUs: 1},
+			workers: []WorkerState{
+				{Address: "a", Capacity: 4, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 1024, FreeCPUs: 4},
+				{Address: "b", Outstanding: 4, Capacity: 4, TotalMemoryMB: 8192, TotalCPUs: 4, FreeMemoryMB: 8192, FreeCPUs: 4},
+			},
+			want: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				got := tt.policy.Place(tt.req, tt.workers)
+				if got.Worker != want {
+					t.Fatalf("placement %d: got %q, want %q", i, got.Worker, want)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleNoCapacity(t *testing.T) {
+	workers := map[string]WorkerState{
+		"a": {Address: "a", Weight: 2, Outstanding: 2, Capacity: 2, TotalMemoryMB: 4096, TotalCPUs: 2, FreeMemoryMB: 4096, FreeCPUs: 2},
+		"b": {Address: "b", Weight: 1, Outstanding: 3, Capacity: 3, TotalMemoryMB: 4096, TotalCPUs: 2, FreeMemoryMB: 4096, FreeCPUs: 2},
+	}
+	state := StateFunc(func(addr string) WorkerState { return workers[addr] })
+
+	for _, name := range []string{PolicyRoundRobin, PolicyLeastLoaded, PolicyWeighted, PolicyResourceFit} {
+		t.Run(name, func(t *testing.T) {
+			policy, err := NewPolicy(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sched := NewScheduler(policy, state, NewHealthTracker(0, 0))
+
+			decision := sched.Schedule(Requirements{MemoryMB: 256, CPUs: 0.5}, []string{"a", "b"})
+			if decision.Placed() {
+				t.Fatalf("expected rejection, got worker %q", decision.Worker)
+			}
+			if !errors.Is(decision.Reason, ErrNoCapacity) {
+				t.Fatalf("got reason %v, want %v", decision.Reason, ErrNoCapacity)
+			}
+		})
+	}
+}
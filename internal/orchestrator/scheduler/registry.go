@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type Factory func() Policy
+
+var (
+	policiesMu sync.RWMutex
+	policies   = map[string]Factory{}
+)
+
+func init() {
+	Register(PolicyRoundRobin, func() Policy { return NewRoundRobin() })
+	Register(PolicyLeastLoaded, func() Policy { return NewLeastLoaded() })
+	Register(PolicyWeighted, func() Policy { return NewWeighted() })
+	Register(PolicyResourceFit, func() Policy { return NewResourceFit() })
+	Register(PolicyImageAffinity, func() Policy { return NewImageAffinity() })
+}
+
+func Register(name string, factory Factory) {
+	policiesMu.Lock()
+	defer policiesMu.Unlock()
+	policies[name] = factory
+}
+
+func NewPolicy(name string) (Policy, error) {
+	if name == "" {
+		name = PolicyRoundRobin
+	}
+	policiesMu.RLock()
+	factory, ok := policies[name]
+	policiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("scheduler policy %q tidak dikenal (tersedia: %v)", name, Policies())
+	}
+	return factory(), nil
+}
+
+func Policies() []string {
+	policiesMu.RLock()
+	defer policiesMu.RUnlock()
+
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,10 +1,82 @@
 package scheduler
 
-import "sync/atomic"
+import (
+	"fmt"
+	"sync/atomic"
+)
 
-type LoadBalancer interface {
-	NextWorker(workers []string) string
+const (
+	PolicyRoundRobin    = "round_robin"
+	PolicyLeastLoaded   = "least_loaded"
+	PolicyWeighted      = "weighted"
+	PolicyResourceFit   = "resource_fit"
+	PolicyImageAffinity = "image_affinity"
+)
+
+type Scheduler struct {
+	policy Policy
+	state  StateSource
+	health *HealthTracker
+}
+
+func NewScheduler(policy Policy, state StateSource, health *HealthTracker) *Scheduler {
+	return &Scheduler{
+		policy: policy,
+		state:  state,
+		health: health,
+	}
+}
+
+func (s *Scheduler) Policy() string {
+	return s.policy.Name()
+}
+
+func (s *Scheduler) Placeable(constraints Constraints, workers []string) []string {
+	matched := make([]string, 0, len(workers))
+	for _, addr := range workers {
+		if constraints.Matches(s.state.State(addr).Labels) {
+			matched = append(matched, addr)
+		}
+	}
+	return matched
 }
+
+func (s *Scheduler) Schedule(req Requirements, workers []string) Decision {
+	if len(workers) == 0 {
+		return Reject(ErrNoWorkers)
+	}
+	placeable := s.Placeable(req.Constraints, workers)
+	if len(placeable) == 0 {
+		return Reject(ErrNoMatchingWorker)
+	}
+
+	available := s.health.Available(placeable)
+	if len(available) == 0 {
+		return Reject(ErrNoHealthyWorker)
+	}
+
+	states := make([]WorkerState, 0, len(available))
+	for _, addr := range available {
+		st := s.state.State(addr)
+		st.Health = s.health.State(addr)
+		states = append(states, st)
+	}
+
+	decision := s.policy.Place(req, states)
+	if !decision.Placed() && decision.Reason == nil {
+		decision.Reason = fmt.Errorf("%w (policy %s)", ErrNoCapacity, s.policy.Name())
+	}
+	return decision
+}
+
+func (s *Scheduler) Requirements(addr string, req Requirements) Requirements {
+	return s.state.State(addr).Resolve(req)
+}
+
+func (s *Scheduler) Cached(addr, image string) bool {
+	return s.state.State(addr).HasImage(image)
+}
+
 type RoundRobin struct {
 	counter uint64
 }
@@ -15,11 +87,25 @@ func NewRoundRobin() *RoundRobin {
 	}
 }
 
-func (r *RoundRobin) NextWorker(workers []string) string {
+func (r *RoundRobin) Name() string {
+	return PolicyRoundRobin
+}
+
+func (r *RoundRobin) Place(req Requirements, workers []WorkerState) Decision {
+	open := make([]WorkerState, 0, len(workers))
+	for _, st := range workers {
+		if !st.Full() {
+			open = append(open, st)
+		}
+	}
+	return Place(r.next(open))
+}
+
+func (r *RoundRobin) next(workers []WorkerState) string {
 	if len(workers) == 0 {
 		return ""
 	}
 	current := atomic.AddUint64(&r.counter, 1)
 	index := (current - 1) % uint64(len(workers))
-	return workers[index]
-}
\ No newline at end of file
+	return workers[index].Address
+}
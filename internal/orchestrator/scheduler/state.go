@@ -0,0 +1,161 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+type WorkerState struct {
+	Address         string
+	Outstanding     int
+	Capacity        int
+	Weight          int
+	TotalMemoryMB   int64
+	TotalCPUs       float64
+	FreeMemoryMB    int64
+	FreeCPUs        float64
+	DefaultMemoryMB int64
+	DefaultCPUs     float64
+	Images          []string
+	Labels          map[string]string
+	Health          CircuitState
+}
+
+func (w WorkerState) Resolve(req Requirements) Requirements {
+	if req.MemoryMB <= 0 {
+		req.MemoryMB = w.DefaultMemoryMB
+	}
+	if req.CPUs <= 0 {
+		req.CPUs = w.DefaultCPUs
+	}
+	return req
+}
+
+func (w WorkerState) HasImage(name string) bool {
+	name = NormalizeImage(name)
+	for _, img := range w.Images {
+		if NormalizeImage(img) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (w WorkerState) Full() bool {
+	return w.Capacity > 0 && w.Outstanding >= w.Capacity
+}
+
+type StateSource interface {
+	State(addr string) WorkerState
+}
+
+type StateFunc func(addr string) WorkerState
+
+func (f StateFunc) State(addr string) WorkerState {
+	return f(addr)
+}
+
+const reservationTTL = time.Minute
+
+type LoadTracker struct {
+	mu           sync.Mutex
+	outstanding  map[string]int
+	reservations map[string][]*Reservation
+}
+
+type Reservation struct {
+	tracker  *LoadTracker
+	addr     string
+	memoryMB int64
+	cpus     float64
+	started  time.Time
+}
+
+func NewLoadTracker() *LoadTracker {
+	return &LoadTracker{
+		outstanding:  make(map[string]int),
+		reservations: make(map[string][]*Reservation),
+	}
+}
+
+func (t *LoadTracker) Reserve(addr string, req Requirements) *Reservation {
+	r := &Reservation{tracker: t, addr: addr, memoryMB: req.MemoryMB, cpus: req.CPUs}
+	t.mu.Lock()
+	t.outstanding[addr]++
+	t.reservations[addr] = append(t.reservations[addr], r)
+	t.mu.Unlock()
+	return r
+}
+
+func (r *Reservation) Started() {
+	r.tracker.mu.Lock()
+	r.started = time.Now()
+	r.tracker.mu.Unlock()
+}
+
+func (r *Reservation) Cancel() {
+	r.tracker.mu.Lock()
+	r.tracker.drop(r)
+	r.tracker.mu.Unlock()
+	r.tracker.Dec(r.addr)
+}
+
+func (t *LoadTracker) drop(r *Reservation) {
+	list := t.reservations[r.addr]
+	for i, x := range list {
+		if x == r {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(list) == 0 {
+		delete(t.reservations, r.addr)
+		return
+	}
+	t.reservations[r.addr] = list
+}
+
+func (t *LoadTracker) Pending(addr string, reportedAt time.Time) (int64, float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var memoryMB int64
+	var cpus float64
+	var pending []*Reservation
+	for _, r := range t.reservations[addr] {
+		if !r.started.IsZero() && (r.started.Before(reportedAt) || time.Since(r.started) > reservationTTL) {
+			continue
+		}
+		memoryMB += r.memoryMB
+		cpus += r.cpus
+		pending = append(pending, r)
+	}
+	if len(pending) == 0 {
+		delete(t.reservations, addr)
+	} else {
+		t.reservations[addr] = pending
+	}
+	return memoryMB, cpus
+}
+
+func (t *LoadTracker) Inc(addr string) {
+	t.mu.Lock()
+	t.outstanding[addr]++
+	t.mu.Unlock()
+}
+
+func (t *LoadTracker) Dec(addr string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.outstanding[addr] <= 1 {
+		delete(t.outstanding, addr)
+		return
+	}
+	t.outstanding[addr]--
+}
+
+func (t *LoadTracker) Outstanding(addr string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.outstanding[addr]
+}
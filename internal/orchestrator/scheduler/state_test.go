@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReservationsSpreadPlacements(t *testing.T) {
+	reported := time.Now()
+	workers := map[string]WorkerState{
+		"a": {Address: "a", Capacity: 4, TotalMemoryMB: 4096, TotalCPUs: 4, FreeMemoryMB: 4096, FreeCPUs: 4, DefaultMemoryMB: 1024, DefaultCPUs: 1},
+		"b": {Address: "b", Capacity: 4, TotalMemoryMB: 4096, TotalCPUs: 4, FreeMemoryMB: 3072, FreeCPUs: 3, DefaultMemoryMB: 1024, DefaultCPUs: 1},
+	}
+	load := NewLoadTracker()
+	state := StateFunc(func(addr string) WorkerState {
+		st := workers[addr]
+		st.Outstanding = load.Outstanding(addr)
+		memoryMB, cpus := load.Pending(addr, reported)
+		st.FreeMemoryMB -= memoryMB
+		st.FreeCPUs -= cpus
+		return st
+	})
+	sched := NewScheduler(NewResourceFit(), state, NewHealthTracker(0, 0))
+
+	var got []string
+	var reservations []*Reservation
+	for range 8 {
+		decision := sched.Schedule(Requirements{}, []string{"a", "b"})
+		if !decision.Placed() {
+			got = append(got, "")
+			continue
+		}
+		got = append(got, decision.Worker)
+		reservations = append(reservations, load.Reserve(decision.Worker, sched.Requirements(decision.Worker, Requirements{})))
+	}
+
+	want := []string{"a", "a", "b", "a", "b", "a", "b", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("placements = %v, want %v", got, want)
+		}
+	}
+
+	for _, r := range reservations {
+		r.Cancel()
+	}
+	if n := load.Outstanding("a") + load.Outstanding("b"); n != 0 {
+		t.Fatalf("outstanding after cancel = %d, want 0", n)
+	}
+	if memoryMB, _ := load.Pending("a", reported); memoryMB != 0 {
+		t.Fatalf("pending memory after cancel = %d, want 0", memoryMB)
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/orchestrator/proxy"
+	"github.com/JullMol/nebula/internal/orchestrator/registry"
+	"github.com/JullMol/nebula/internal/orchestrator/scheduler"
+	"github.com/JullMol/nebula/internal/platform/database"
+)
+
+type Server struct {
+	pb.UnimplementedOrchestratorServer
+	db       *gorm.DB
+	registry *registry.Registry
+	health   *scheduler.HealthTracker
+	proxy    *proxy.ProxyService
+}
+
+func NewServer(db *gorm.DB, reg *registry.Registry, health *scheduler.HealthTracker, proxySvc *proxy.ProxyService) *Server {
+	return &Server{
+		db:       db,
+		registry: reg,
+		health:   health,
+		proxy:    proxySvc,
+	}
+}
+
+func (s *Server) ListWorkers(ctx context.Context, req *pb.ListWorkersRequest) (*pb.ListWorkersResponse, error) {
+	health := s.health.Snapshot()
+	resp := &pb.ListWorkersResponse{}
+	for _, w := range s.registry.Snapshot() {
+		ws := &pb.WorkerStatus{
+			Name:          w.Name,
+			Address:       w.Address,
+			Capacity:      int32(w.Capacity),
+			Labels:        w.Labels,
+			Running:       int32(w.Running),
+			Static:        w.Static,
+			Weight:        int32(w.Weight),
+			TotalMemoryMb: w.TotalMemoryMB,
+			TotalCpus:     w.TotalCPUs,
+			FreeMemoryMb:  w.FreeMemoryMB,
+			FreeCpus:      w.FreeCPUs,
+			Images:        w.Images,
+			RegisteredAt:  timestamppb.New(w.RegisteredAt),
+			LastHeartbeat: timestamppb.New(w.LastHeartbeat),
+		}
+		if h, ok := health[w.Address]; ok {
+			ws.Health = &pb.WorkerHealth{
+				State:               string(h.State),
+				ConsecutiveFailures: int32(h.ConsecutiveFailures),
+				LastError:           h.LastError,
+			}
+			if !h.OpenedAt.IsZero() {
+				ws.Health.OpenedAt = timestamppb.New(h.OpenedAt)
+			}
+		}
+		resp.Workers = append(resp.Workers, ws)
+	}
+	return resp, nil
+}
+
+func (s *Server) CheckPlacement(ctx context.Context, req *pb.CheckPlacementRequest) (*pb.CheckPlacementResponse, error) {
+	matched := s.proxy.Placeable(scheduler.Constraints{
+		NodeSelector: req.NodeSelector,
+		AntiAffinity: req.AntiAffinity,
+	})
+	return &pb.CheckPlacementResponse{
+		Placeable:       len(matched) > 0,
+		MatchingWorkers: int32(len(matched)),
+	}, nil
+}
+
+func (s *Server) StopJob(ctx context.Context, req *pb.StopJobRequest) (*pb.StopJobResponse, error) {
+	job, err := s.findJob(req.JobId)
+	if err != nil {
+		return nil, err
+	}
+	if job.ContainerID == "" || job.WorkerAddr == "" {
+		return &pb.StopJobResponse{Stopped: false}, nil
+	}
+
+	if err := s.proxy.StopOn(ctx, job.WorkerAddr, job.ContainerID); err != nil {
+		return nil, err
+	}
+	return &pb.StopJobResponse{Stopped: true}, nil
+}
+
+func (s *Server) StreamJobLogs(req *pb.StreamJobLogsRequest, stream pb.Orchestrator_StreamJobLogsServer) error {
+	job, err := s.findJob(req.JobId)
+	if err != nil {
+		return err
+	}
+	if job.ContainerID == "" || job.WorkerAddr == "" {
+		return status.Error(codes.FailedPrecondition, "job belum punya container")
+	}
+
+	return s.proxy.StreamLogsFrom(stream.Context(), job.WorkerAddr, job.ContainerID, stream.Send)
+}
+
+func (s *Server) findJob(jobID string) (*database.Job, error) {
+	var job database.Job
+	if err := s.db.First(&job, "id = ?", jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "job tidak ditemukan")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &job, nil
+}
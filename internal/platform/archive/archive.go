@@ -0,0 +1,254 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/JullMol/nebula/pkg/config"
+)
+
+const (
+	mb = 1024 * 1024
+
+	defaultMaxArchiveMB   = 8
+	defaultMaxExtractedMB = 64
+	defaultMaxFiles       = 1000
+)
+
+var (
+	ErrUnsupported       = errors.New("format archive tidak dikenal (pakai tar, tar.gz atau zip)")
+	ErrArchiveTooLarge   = errors.New("archive melebihi batas ukuran")
+	ErrExtractedTooLarge = errors.New("isi archive melebihi batas ukuran")
+	ErrTooManyFiles      = errors.New("archive berisi terlalu banyak file")
+	ErrUnsafePath        = errors.New("path di archive tidak aman")
+)
+
+type Limits struct {
+	MaxArchiveBytes   int64
+	MaxExtractedBytes int64
+	MaxFiles          int
+}
+
+func LimitsFrom(cfg config.ArchiveConfig) Limits {
+	l := Limits{
+		MaxArchiveBytes:   cfg.MaxArchiveMB * mb,
+		MaxExtractedBytes: cfg.MaxExtractedMB * mb,
+		MaxFiles:          cfg.MaxFiles,
+	}
+	if l.MaxArchiveBytes <= 0 {
+		l.MaxArchiveBytes = defaultMaxArchiveMB * mb
+	}
+	if l.MaxExtractedBytes <= 0 {
+		l.MaxExtractedBytes = defaultMaxExtractedMB * mb
+	}
+	if l.MaxFiles <= 0 {
+		l.MaxFiles = defaultMaxFiles
+	}
+	return l
+}
+
+type entry struct {
+	name       string
+	dir        bool
+	executable bool
+	body       io.Reader
+}
+
+func CleanPath(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || strings.Contains(name, ":") {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+	clean := path.Clean(name)
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+	if clean == "." {
+		return "", nil
+	}
+	return clean, nil
+}
+
+func List(data []byte, limits Limits) ([]string, error) {
+	var files []string
+	err := walk(data, limits, func(e entry) error {
+		if e.dir {
+			return nil
+		}
+		files = append(files, e.name)
+		_, err := io.Copy(io.Discard, e.body)
+		return err
+	})
+	return files, err
+}
+
+func Tar(data []byte, limits Limits, tw *tar.Writer) error {
+	now := time.Now()
+	return walk(data, limits, func(e entry) error {
+		if e.dir {
+			return tw.WriteHeader(&tar.Header{Name: e.name + "/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: now})
+		}
+
+		body, err := io.ReadAll(e.body)
+		if err != nil {
+			return err
+		}
+		mode := int64(0644)
+		if e.executable {
+			mode = 0755
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: e.name, Typeflag: tar.TypeReg, Mode: mode, Size: int64(len(body)), ModTime: now}); err != nil {
+			return err
+		}
+		_, err = tw.Write(body)
+		return err
+	})
+}
+
+func walk(data []byte, limits Limits, fn func(entry) error) error {
+	if limits.MaxArchiveBytes > 0 && int64(len(data)) > limits.MaxArchiveBytes {
+		return fmt.Errorf("%w (%d MB)", ErrArchiveTooLarge, limits.MaxArchiveBytes/mb)
+	}
+
+	budget := &budget{limits: limits}
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) || bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		return walkZip(data, budget, fn)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("gagal baca gzip: %w", err)
+		}
+		defer gz.Close()
+		return walkTar(gz, budget, fn)
+	case len(data) > 262 && string(data[257:262]) == "ustar":
+		return walkTar(bytes.NewReader(data), budget, fn)
+	}
+	return ErrUnsupported
+}
+
+func walkTar(r io.Reader, b *budget, fn func(entry) error) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("gagal baca tar: %w", err)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir, tar.TypeReg, tar.TypeRegA:
+		case tar.TypeXGlobalHeader:
+			continue
+		default:
+			return fmt.Errorf("%w: %s bukan file biasa", ErrUnsafePath, hdr.Name)
+		}
+
+		name, err := CleanPath(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		if err := b.file(); err != nil {
+			return err
+		}
+
+		e := entry{name: name, dir: hdr.Typeflag == tar.TypeDir, executable: hdr.Mode&0111 != 0}
+		if !e.dir {
+			e.body = b.reader(tr)
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+}
+
+func walkZip(data []byte, b *budget, fn func(entry) error) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("gagal baca zip: %w", err)
+	}
+
+	for _, f := range zr.File {
+		mode := f.Mode()
+		if mode&os.ModeType&^os.ModeDir != 0 {
+			return fmt.Errorf("%w: %s bukan file biasa", ErrUnsafePath, f.Name)
+		}
+
+		name, err := CleanPath(f.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		if err := b.file(); err != nil {
+			return err
+		}
+
+		e := entry{name: name, dir: mode.IsDir(), executable: mode&0111 != 0}
+		if e.dir {
+			if err := fn(e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("gagal buka %s: %w", f.Name, err)
+		}
+		e.body = b.reader(rc)
+		err = fn(e)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type budget struct {
+	limits    Limits
+	files     int
+	extracted int64
+}
+
+func (b *budget) file() error {
+	b.files++
+	if b.limits.MaxFiles > 0 && b.files > b.limits.MaxFiles {
+		return fmt.Errorf("%w (maksimal %d)", ErrTooManyFiles, b.limits.MaxFiles)
+	}
+	return nil
+}
+
+func (b *budget) reader(r io.Reader) io.Reader {
+	return &budgetReader{r: r, b: b}
+}
+
+type budgetReader struct {
+	r io.Reader
+	b *budget
+}
+
+func (br *budgetReader) Read(p []byte) (int, error) {
+	n, err := br.r.Read(p)
+	br.b.extracted += int64(n)
+	if max := br.b.limits.MaxExtractedBytes; max > 0 && br.b.extracted > max {
+		return n, fmt.Errorf("%w (%d MB)", ErrExtractedTooLarge, max/mb)
+	}
+	return n, err
+}
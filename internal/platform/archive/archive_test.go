@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type file struct {
+	name     string
+	body     string
+	typeflag byte
+	mode     os.FileMode
+	linkname string
+}
+
+func tarball(t *testing.T, files ...file) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		typeflag := f.typeflag
+		if typeflag == 0 {
+			typeflag = tar.TypeReg
+		}
+		hdr := &tar.Header{Name: f.name, Typeflag: typeflag, Mode: 0644, Linkname: f.linkname}
+		if typeflag == tar.TypeReg {
+			hdr.Size = int64(len(f.body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(f.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zipball(t *testing.T, files ...file) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		hdr := &zip.FileHeader{Name: f.name, Method: zip.Deflate}
+		mode := f.mode
+		if mode == 0 {
+			mode = 0644
+		}
+		hdr.SetMode(mode)
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		err  bool
+	}{
+		{name: "main.py", want: "main.py"},
+		{name: "./src/main.go", want: "src/main.go"},
+		{name: "src/../main.go", want: "main.go"},
+		{name: "src\\util\\io.py", want: "src/util/io.py"},
+		{name: "./", want: ""},
+		{name: "../x", err: true},
+		{name: "..", err: true},
+		{name: "a/../../x", err: true},
+		{name: "/etc/passwd", err: true},
+		{name: "C:/Windows/win.ini", err: true},
+		{name: "C:evil", err: true},
+		{name: "..\\x", err: true},
+		{name: "\\etc\\passwd", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CleanPath(tt.name)
+			if tt.err {
+				if !errors.Is(err, ErrUnsafePath) {
+					t.Fatalf("got %q, %v; want %v", got, err, ErrUnsafePath)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRejectsUnsafeEntries(t *testing.T) {
+	limits := Limits{MaxArchiveBytes: mb, MaxExtractedBytes: mb, MaxFiles: 10}
+	tests := []struct {
+		name string
+		data func(t *testing.T) []byte
+	}{
+		{"tar parent dir", func(t *testing.T) []byte { return tarball(t, file{name: "../x", body: "x"}) }},
+		{"tar nested escape", func(t *testing.T) []byte { return tarball(t, file{name: "a/../../x", body: "x"}) }},
+		{"tar absolute path", func(t *testing.T) []byte { return tarball(t, file{name: "/tmp/x", body: "x"}) }},
+		{"tar drive path", func(t *testing.T) []byte { return tarball(t, file{name: "C:/x", body: "x"}) }},
+		{"tar backslash escape", func(t *testing.T) []byte { return tarball(t, file{name: "..\\x", body: "x"}) }},
+		{"tar symlink", func(t *testing.T) []byte {
+			return tarball(t, file{name: "link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"})
+		}},
+		{"tar hardlink", func(t *testing.T) []byte {
+			return tarball(t, file{name: "main.py", body: "x"}, file{name: "link", typeflag: tar.TypeLink, linkname: "main.py"})
+		}},
+		{"zip parent dir", func(t *testing.T) []byte { return zipball(t, file{name: "../x", body: "x"}) }},
+		{"zip nested escape", func(t *testing.T) []byte { return zipball(t, file{name: "a/../../x", body: "x"}) }},
+		{"zip absolute path", func(t *testing.T) []byte { return zipball(t, file{name: "/tmp/x", body: "x"}) }},
+		{"zip drive path", func(t *testing.T) []byte { return zipball(t, file{name: "C:\\x", body: "x"}) }},
+		{"zip backslash escape", func(t *testing.T) []byte { return zipball(t, file{name: "a\\..\\..\\x", body: "x"}) }},
+		{"zip symlink", func(t *testing.T) []byte {
+			return zipball(t, file{name: "link", body: "/etc/passwd", mode: os.ModeSymlink | 0777})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := List(tt.data(t), limits)
+			if !errors.Is(err, ErrUnsafePath) {
+				t.Fatalf("got %v, %v; want %v", files, err, ErrUnsafePath)
+			}
+		})
+	}
+}
+
+func TestListAcceptsSafeArchives(t *testing.T) {
+	limits := Limits{MaxArchiveBytes: mb, MaxExtractedBytes: mb, MaxFiles: 10}
+	want := []string{"main.py", "utils/io.py"}
+
+	for name, data := range map[string][]byte{
+		"tar": tarball(t, file{name: "utils/", typeflag: tar.TypeDir}, file{name: "main.py", body: "print(1)"}, file{name: "./utils/io.py", body: "x"}),
+		"zip": zipball(t, file{name: "main.py", body: "print(1)"}, file{name: "utils\\io.py", body: "x"}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			files, err := List(data, limits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(files, want) {
+				t.Fatalf("got %v, want %v", files, want)
+			}
+		})
+	}
+}
+
+func TestListLimits(t *testing.T) {
+	many := make([]file, 4)
+	for i := range many {
+		many[i] = file{name: string(rune('a'+i)) + ".txt", body: "x"}
+	}
+	bomb := file{name: "bomb.txt", body: strings.Repeat("0", 2*mb)}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		limits Limits
+		want   error
+	}{
+		{"tar file count", tarball(t, many...), Limits{MaxFiles: 3}, ErrTooManyFiles},
+		{"zip file count", zipball(t, many...), Limits{MaxFiles: 3}, ErrTooManyFiles},
+		{"zip bomb", zipball(t, bomb), Limits{MaxArchiveBytes: 64 * 1024, MaxExtractedBytes: mb}, ErrExtractedTooLarge},
+		{"tar extracted size", tarball(t, bomb), Limits{MaxExtractedBytes: mb}, ErrExtractedTooLarge},
+		{"archive size", tarball(t, bomb), Limits{MaxArchiveBytes: mb}, ErrArchiveTooLarge},
+		{"unknown format", []byte("print('hello')"), Limits{}, ErrUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := List(tt.data, tt.limits)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -7,13 +7,37 @@ import (
 )
 
 type Job struct {
-	ID        string    `gorm:"primaryKey" json:"id"`
-	Image     string    `json:"image"`
-	Command   string    `json:"command"`
-	Status    string    `json:"status"` 
-	Result    string    `json:"result"` 
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            string     `gorm:"primaryKey" json:"id"`
+	Image         string     `json:"image"`
+	Language      string     `json:"language"`
+	Version       string     `json:"version"`
+	Command       string     `json:"command"`
+	Status        string     `json:"status"`
+	Result        string     `json:"result"`
+	Stdout        string     `json:"stdout"`
+	Stderr        string     `json:"stderr"`
+	ExitCode      *int       `json:"exit_code"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	DurationMs    int64      `json:"duration_ms"`
+	Attempts      int        `json:"attempts"`
+	ContainerID   string     `json:"container_id"`
+	WorkerAddr    string     `json:"worker_addr"`
+	CompileOutput string     `gorm:"type:text" json:"compile_output"`
+	CompileMs     int64      `json:"compile_ms"`
+	Judge         string     `gorm:"type:text" json:"-"`
+	Spec          string     `gorm:"type:text" json:"-"`
+	LeaderToken   int64      `gorm:"not null;default:0" json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case "completed", "failed", "cancelled", "timed_out", "oom_killed", "compile_error":
+		return true
+	}
+	return false
 }
 
 func NewConnection(dsn string) (*gorm.DB, error) {
@@ -1,16 +1,25 @@
 package docker
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
+	"path"
 	"strings"
+	"time"
 
+	"github.com/JullMol/nebula/internal/platform/runtimes"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +27,44 @@ type Client struct {
 	cli *client.Client
 }
 
+type Limits struct {
+	MemoryBytes int64
+	NanoCPUs    int64
+	PidsLimit   int64
+	TmpfsBytes  int64
+}
+
+const (
+	LabelManaged = "nebula.managed"
+	LabelJobID   = "nebula.job_id"
+	LabelVolume  = "nebula.volume"
+)
+
+type ContainerSpec struct {
+	JobID    string
+	Image    string
+	Command  string
+	Files    []byte
+	Volume   string
+	ReadOnly bool
+	Limits   Limits
+	Sandbox  SandboxProfile
+}
+
+type ManagedContainer struct {
+	ID      string
+	JobID   string
+	State   string
+	Volume  string
+	Created time.Time
+}
+
+type ManagedVolume struct {
+	Name    string
+	JobID   string
+	Created time.Time
+}
+
 func NewClient() (*Client, error) {
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
@@ -26,74 +73,297 @@ func NewClient() (*Client, error) {
 	return &Client{cli: cli}, nil
 }
 
-func (c *Client) RunContainer(ctx context.Context, imageName string, command string, code string) (string, error) {
-	reader, err := c.cli.ImagePull(ctx, imageName, image.PullOptions{})
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.cli.Ping(ctx)
+	return err
+}
+
+func (c *Client) HostResources(ctx context.Context) (int64, float64, error) {
+	info, err := c.cli.Info(ctx)
 	if err != nil {
-		return "", fmt.Errorf("gagal pull image: %w", err)
+		return 0, 0, err
 	}
-	io.Copy(io.Discard, reader)
-	reader.Close()
-
-	var hostConfig *container.HostConfig
-	if code != "" {
-		cwd, _ := os.Getwd()
-		tempDir := filepath.Join(cwd, "temp_jobs", uuid.New().String())
-		if err := os.MkdirAll(tempDir, 0755); err != nil {
-			return "", fmt.Errorf("gagal bikin folder temp: %w", err)
-		}
+	return info.MemTotal / (1024 * 1024), float64(info.NCPU), nil
+}
 
-		fileName := "main.txt"
-		runCommand := ""
+func (c *Client) RunContainer(ctx context.Context, spec ContainerSpec) (string, error) {
+	imageName, command := spec.Image, spec.Command
+	if command == "" {
+		return "", fmt.Errorf("command kosong")
+	}
 
-		if strings.Contains(imageName, "python") {
-			fileName = "main.py"
-			runCommand = "python -u"
-		} else if strings.Contains(imageName, "node") {
-			fileName = "main.js"
-			runCommand = "node"
-		}
+	if err := c.EnsureImage(ctx, imageName); err != nil {
+		return "", err
+	}
 
-		filePath := filepath.Join(tempDir, fileName)
-		if err := os.WriteFile(filePath, []byte(code), 0644); err != nil {
-			return "", fmt.Errorf("gagal tulis file: %w", err)
+	hostConfig := &container.HostConfig{
+		Resources: resourcesFor(spec.Limits),
+	}
+	if spec.Limits.TmpfsBytes > 0 {
+		hostConfig.Tmpfs = map[string]string{
+			"/tmp": tmpfsOptions("rw,noexec,nosuid", spec.Limits.TmpfsBytes),
 		}
+	}
 
-		fmt.Printf("📂 Script (%s) dibuat di Host: %s\n", fileName, filePath)
+	labels := map[string]string{
+		LabelManaged: "true",
+		LabelJobID:   spec.JobID,
+	}
 
-		hostConfig = &container.HostConfig{
-			Binds: []string{
-				fmt.Sprintf("%s:/app", tempDir),
-			},
+	vol := spec.Volume
+	if vol == "" && len(spec.Files) > 0 {
+		name, err := c.CreateVolume(ctx, spec.JobID)
+		if err != nil {
+			return "", err
 		}
-
-		if command == "" {
-			command = fmt.Sprintf("%s /app/%s", runCommand, fileName)
+		vol = name
+	}
+	if len(spec.Files) > 0 {
+		fill := spec
+		fill.Volume = vol
+		if err := c.FillVolume(ctx, fill); err != nil {
+			c.RemoveVolume(context.Background(), vol)
+			return "", err
+		}
+	}
+	if vol != "" {
+		labels[LabelVolume] = vol
+		hostConfig.Mounts = []mount.Mount{
+			{Type: mount.TypeVolume, Source: vol, Target: runtimes.SourceDir, ReadOnly: spec.ReadOnly},
 		}
 	}
+	containerConfig := &container.Config{
+		Image:  imageName,
+		Cmd:    []string{"sh", "-c", command},
+		Tty:    false,
+		Labels: labels,
+	}
+	applySandbox(spec.Sandbox, spec.Limits, containerConfig, hostConfig)
+
 	resp, err := c.cli.ContainerCreate(ctx, 
-		&container.Config{
-			Image: imageName,
-			Cmd:   []string{"sh", "-c", command},
-			Tty:   false,
-		}, 
+		containerConfig, 
 		hostConfig,
 		nil, nil, "",
 	)
 	if err != nil {
+		c.RemoveVolume(context.Background(), vol)
 		return "", fmt.Errorf("gagal create container: %w", err)
 	}
 
 	if err := c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		c.RemoveJob(context.Background(), resp.ID)
 		return "", fmt.Errorf("gagal start container: %w", err)
 	}
 
 	return resp.ID, nil
 }
 
+func (c *Client) FillVolume(ctx context.Context, spec ContainerSpec) error {
+	if err := c.EnsureImage(ctx, spec.Image); err != nil {
+		return err
+	}
+
+	resp, err := c.cli.ContainerCreate(ctx, &container.Config{
+		Image: spec.Image,
+		Cmd:   []string{"true"},
+		Labels: map[string]string{
+			LabelManaged: "true",
+			LabelJobID:   spec.JobID,
+			LabelVolume:  spec.Volume,
+		},
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{
+			{Type: mount.TypeVolume, Source: spec.Volume, Target: runtimes.SourceDir},
+		},
+	}, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("gagal create container pengisi volume: %w", err)
+	}
+	defer c.RemoveContainer(context.Background(), resp.ID, true)
+
+	files, err := rebase(spec.Files, runtimes.SourceDir)
+	if err != nil {
+		return fmt.Errorf("gagal siapkan file job: %w", err)
+	}
+	if err := c.cli.CopyToContainer(ctx, resp.ID, "/", bytes.NewReader(files), types.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("gagal copy file job ke volume: %w", err)
+	}
+	return nil
+}
+
+func rebase(files []byte, dir string) ([]byte, error) {
+	dir = strings.TrimPrefix(path.Clean(dir), "/")
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	tr := tar.NewReader(bytes.NewReader(files))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := path.Join(dir, hdr.Name)
+		if hdr.Typeflag == tar.TypeDir {
+			name += "/"
+		}
+		hdr.Name = name
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Client) CreateVolume(ctx context.Context, jobID string) (string, error) {
+	vol, err := c.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name: "nebula-job-" + uuid.New().String(),
+		Labels: map[string]string{
+			LabelManaged: "true",
+			LabelJobID:   jobID,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("gagal bikin volume job: %w", err)
+	}
+	return vol.Name, nil
+}
+
+func (c *Client) RemoveVolume(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := c.cli.VolumeRemove(ctx, name, true); err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		fmt.Printf("⚠️ Gagal hapus volume %s: %v\n", name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) ListManagedVolumes(ctx context.Context) ([]ManagedVolume, error) {
+	resp, err := c.cli.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", LabelManaged+"=true")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make([]ManagedVolume, 0, len(resp.Volumes))
+	for _, vol := range resp.Volumes {
+		created, _ := time.Parse(time.RFC3339, vol.CreatedAt)
+		managed = append(managed, ManagedVolume{
+			Name:    vol.Name,
+			JobID:   vol.Labels[LabelJobID],
+			Created: created,
+		})
+	}
+	return managed, nil
+}
+
+func (c *Client) EnsureImage(ctx context.Context, imageName string) error {
+	if _, _, err := c.cli.ImageInspectWithRaw(ctx, imageName); err == nil {
+		return nil
+	}
+
+	reader, err := c.cli.ImagePull(ctx, imageName, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("gagal pull image: %w", err)
+	}
+	io.Copy(io.Discard, reader)
+	reader.Close()
+	return nil
+}
+
+func (c *Client) ListImages(ctx context.Context) ([]string, error) {
+	summaries, err := c.cli.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, img := range summaries {
+		for _, tag := range img.RepoTags {
+			if tag != "<none>:<none>" {
+				names = append(names, tag)
+			}
+		}
+	}
+	return names, nil
+}
+
+func resourcesFor(l Limits) container.Resources {
+	res := container.Resources{
+		NanoCPUs: l.NanoCPUs,
+	}
+	if l.MemoryBytes > 0 {
+		res.Memory = l.MemoryBytes
+		res.MemorySwap = l.MemoryBytes
+	}
+	if l.PidsLimit > 0 {
+		pids := l.PidsLimit
+		res.PidsLimit = &pids
+	}
+	return res
+}
+
 func (c *Client) StopContainer(ctx context.Context, containerID string) error {
 	return c.cli.ContainerStop(ctx, containerID, container.StopOptions{})
 }
 
+func (c *Client) RemoveContainer(ctx context.Context, containerID string, force bool) error {
+	return c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: force})
+}
+
+func (c *Client) RemoveJob(ctx context.Context, containerID string) error {
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	if err := c.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true}); err != nil {
+		return err
+	}
+	if info.Config != nil {
+		c.RemoveVolume(ctx, info.Config.Labels[LabelVolume])
+	}
+	return nil
+}
+
+func (c *Client) ListManaged(ctx context.Context) ([]ManagedContainer, error) {
+	list, err := c.cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", LabelManaged+"=true")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make([]ManagedContainer, 0, len(list))
+	for _, ctr := range list {
+		managed = append(managed, ManagedContainer{
+			ID:      ctr.ID,
+			JobID:   ctr.Labels[LabelJobID],
+			State:   ctr.State,
+			Volume:  ctr.Labels[LabelVolume],
+			Created: time.Unix(ctr.Created, 0),
+		})
+	}
+	return managed, nil
+}
+
+func (c *Client) KillContainer(ctx context.Context, containerID string) error {
+	return c.cli.ContainerKill(ctx, containerID, "SIGKILL")
+}
+
 func (c *Client) WaitContainer(ctx context.Context, containerID string) error {
 	statusCh, errCh := c.cli.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
 	select {
@@ -104,16 +374,91 @@ func (c *Client) WaitContainer(ctx context.Context, containerID string) error {
 	}
 }
 
-func (c *Client) GetLogs(ctx context.Context, containerID string) (string, error) {
+func (c *Client) InspectState(ctx context.Context, containerID string) (*types.ContainerState, error) {
+	info, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	return info.State, nil
+}
+
+type Logs struct {
+	Stdout   string
+	Stderr   string
+	Combined string
+}
+
+func (c *Client) GetLogs(ctx context.Context, containerID string) (*Logs, error) {
+	return c.GetLogsLimited(ctx, containerID, 0)
+}
+
+func (c *Client) GetLogsLimited(ctx context.Context, containerID string, maxBytes int) (*Logs, error) {
 	out, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{ShowStdout: true, ShowStderr: true})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer out.Close()
 
-	logs, err := io.ReadAll(out)
+	var stdout, stderr, combined bytes.Buffer
+	w := func(buf *bytes.Buffer) io.Writer { return &cappedWriter{buf: buf, max: maxBytes} }
+	if _, err := stdcopy.StdCopy(io.MultiWriter(w(&stdout), w(&combined)), io.MultiWriter(w(&stderr), w(&combined)), out); err != nil {
+		return nil, err
+	}
+	return &Logs{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Combined: combined.String(),
+	}, nil
+}
+
+func (c *Client) LastStderrLine(ctx context.Context, containerID string) (string, error) {
+	out, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{ShowStderr: true, Tail: "1"})
 	if err != nil {
 		return "", err
 	}
-	return string(logs), nil
-}
\ No newline at end of file
+	defer out.Close()
+
+	var stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(io.Discard, &stderr, out); err != nil {
+		return "", err
+	}
+	return stderr.String(), nil
+}
+
+type cappedWriter struct {
+	buf *bytes.Buffer
+	max int
+}
+
+func (w *cappedWriter) Write(p []byte) (int, error) {
+	if w.max > 0 {
+		if room := w.max - w.buf.Len(); room < len(p) {
+			w.buf.Write(p[:max(room, 0)])
+			return len(p), nil
+		}
+	}
+	return w.buf.Write(p)
+}
+
+type streamWriter struct {
+	stream string
+	onData func(stream string, data []byte) error
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	if err := w.onData(w.stream, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *Client) FollowLogs(ctx context.Context, containerID string, onData func(stream string, data []byte) error) error {
+	out, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{ShowStdout: true, ShowStderr: true, Follow: true})
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = stdcopy.StdCopy(streamWriter{"stdout", onData}, streamWriter{"stderr", onData}, out)
+	return err
+}
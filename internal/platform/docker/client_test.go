@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestRebaseKeepsRootMode(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0777})
+	tw.WriteHeader(&tar.Header{Name: "src/", Typeflag: tar.TypeDir, Mode: 0755})
+	tw.WriteHeader(&tar.Header{Name: "src/main.go", Typeflag: tar.TypeReg, Mode: 0644, Size: 12})
+	tw.Write([]byte("package main"))
+	tw.Close()
+
+	files, err := rebase(buf.Bytes(), "/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name string
+		mode int64
+		body string
+	}{
+		{"app/", 0777, ""},
+		{"app/src/", 0755, ""},
+		{"app/src/main.go", 0644, "package main"},
+	}
+	tr := tar.NewReader(bytes.NewReader(files))
+	for i, w := range want {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatalf("entry %d: %v", i, err)
+		}
+		body, _ := io.ReadAll(tr)
+		if hdr.Name != w.name || hdr.Mode != w.mode || string(body) != w.body {
+			t.Fatalf("entry %d: got %q mode %o body %q, want %q mode %o body %q", i, hdr.Name, hdr.Mode, body, w.name, w.mode, w.body)
+		}
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Fatalf("expected end of archive, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	ProfileHardened = "hardened"
+	ProfileStandard = "standard"
+
+	sandboxWorkdir = "/work"
+)
+
+type SandboxProfile struct {
+	Name            string
+	NetworkDisabled bool
+	ReadOnlyRootfs  bool
+	DropAllCaps     bool
+	NoNewPrivileges bool
+	User            string
+	SeccompProfile  string
+}
+
+var Profiles = map[string]SandboxProfile{
+	ProfileHardened: {
+		Name:            ProfileHardened,
+		NetworkDisabled: true,
+		ReadOnlyRootfs:  true,
+		DropAllCaps:     true,
+		NoNewPrivileges: true,
+		User:            "65534:65534",
+	},
+	ProfileStandard: {
+		Name: ProfileStandard,
+	},
+}
+
+func applySandbox(p SandboxProfile, limits Limits, cfg *container.Config, hostConfig *container.HostConfig) {
+	if p.NetworkDisabled {
+		cfg.NetworkDisabled = true
+		hostConfig.NetworkMode = "none"
+	}
+	if p.User != "" {
+		cfg.User = p.User
+	}
+	if p.DropAllCaps {
+		hostConfig.CapDrop = []string{"ALL"}
+	}
+	if p.NoNewPrivileges {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "no-new-privileges:true")
+	}
+	if p.SeccompProfile != "" {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+p.SeccompProfile)
+	}
+	if p.ReadOnlyRootfs {
+		hostConfig.ReadonlyRootfs = true
+		if hostConfig.Tmpfs == nil {
+			hostConfig.Tmpfs = map[string]string{}
+		}
+		hostConfig.Tmpfs["/tmp"] = tmpfsOptions("rw,noexec,nosuid,mode=1777", limits.TmpfsBytes)
+		hostConfig.Tmpfs[sandboxWorkdir] = tmpfsOptions("rw,nosuid,mode=1777", limits.TmpfsBytes)
+		cfg.WorkingDir = sandboxWorkdir
+	}
+}
+
+func tmpfsOptions(base string, size int64) string {
+	if size <= 0 {
+		return base
+	}
+	return fmt.Sprintf("%s,size=%d", base, size)
+}
@@ -0,0 +1,209 @@
+package judge
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CompareExact      = "exact"
+	CompareWhitespace = "whitespace"
+	CompareFloat      = "float"
+
+	VerdictAccepted     = "accepted"
+	VerdictWrongAnswer  = "wrong_answer"
+	VerdictTimeLimit    = "time_limit_exceeded"
+	VerdictMemoryLimit  = "memory_limit_exceeded"
+	VerdictRuntimeError = "runtime_error"
+	VerdictSkipped      = "skipped"
+
+	DefaultTolerance = 1e-6
+	DefaultTimeLimit = 2 * time.Second
+	CaseKillMargin   = 500 * time.Millisecond
+	CaseOverhead     = 2 * time.Second
+	MaxTests         = 100
+	MaxInputBytes    = 4 * 1024 * 1024
+
+	maxReportOutput = 4 * 1024
+)
+
+var ErrInvalidTests = errors.New("test case tidak valid")
+
+type TestCase struct {
+	Input          string  `json:"input"`
+	ExpectedOutput string  `json:"expected_output"`
+	Compare        string  `json:"compare,omitempty"`
+	Tolerance      float64 `json:"tolerance,omitempty"`
+	TimeLimitMs    int     `json:"time_limit_ms,omitempty"`
+}
+
+func (t TestCase) TimeLimit() time.Duration {
+	if t.TimeLimitMs <= 0 {
+		return DefaultTimeLimit
+	}
+	return time.Duration(t.TimeLimitMs) * time.Millisecond
+}
+
+type Outcome struct {
+	Stdout    string
+	Stderr    string
+	ExitCode  int
+	TimeMs    int64
+	MemoryKB  int64
+	TimedOut  bool
+	OOMKilled bool
+	Ran       bool
+}
+
+type CaseResult struct {
+	Index    int    `json:"index"`
+	Verdict  string `json:"verdict"`
+	Passed   bool   `json:"passed"`
+	ExitCode int    `json:"exit_code"`
+	TimeMs   int64  `json:"time_ms"`
+	MemoryKB int64  `json:"memory_kb"`
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+}
+
+type Report struct {
+	Verdict     string       `json:"verdict"`
+	Passed      int          `json:"passed"`
+	Total       int          `json:"total"`
+	MaxTimeMs   int64        `json:"max_time_ms"`
+	MaxMemoryKB int64        `json:"max_memory_kb"`
+	Cases       []CaseResult `json:"cases"`
+}
+
+func Validate(tests []TestCase) error {
+	if len(tests) > MaxTests {
+		return fmt.Errorf("%w: maksimal %d test", ErrInvalidTests, MaxTests)
+	}
+	total := 0
+	for i, t := range tests {
+		switch t.Compare {
+		case "", CompareExact, CompareWhitespace, CompareFloat:
+		default:
+			return fmt.Errorf("%w: test %d compare %q tidak dikenal (exact, whitespace, float)", ErrInvalidTests, i, t.Compare)
+		}
+		if t.Tolerance < 0 || t.TimeLimitMs < 0 {
+			return fmt.Errorf("%w: test %d tolerance/time_limit_ms tidak boleh negatif", ErrInvalidTests, i)
+		}
+		total += len(t.Input)
+	}
+	if total > MaxInputBytes {
+		return fmt.Errorf("%w: total input melebihi %d MB", ErrInvalidTests, MaxInputBytes/(1024*1024))
+	}
+	return nil
+}
+
+func TotalTimeLimit(tests []TestCase) time.Duration {
+	var total time.Duration
+	for _, t := range tests {
+		total += t.TimeLimit()
+	}
+	return total
+}
+
+func Budget(tests []TestCase) time.Duration {
+	return TotalTimeLimit(tests) + time.Duration(len(tests))*CaseOverhead
+}
+
+func Evaluate(tests []TestCase, outcomes []Outcome) Report {
+	report := Report{Verdict: VerdictAccepted, Total: len(tests)}
+	for i, t := range tests {
+		var o Outcome
+		if i < len(outcomes) {
+			o = outcomes[i]
+		}
+
+		res := CaseResult{
+			Index:    i,
+			ExitCode: o.ExitCode,
+			TimeMs:   o.TimeMs,
+			MemoryKB: o.MemoryKB,
+			Stdout:   truncate(o.Stdout),
+			Stderr:   truncate(o.Stderr),
+		}
+		switch {
+		case !o.Ran:
+			res.Verdict = VerdictSkipped
+		case o.OOMKilled:
+			res.Verdict = VerdictMemoryLimit
+		case o.TimedOut || time.Duration(o.TimeMs)*time.Millisecond > t.TimeLimit():
+			res.Verdict = VerdictTimeLimit
+		case o.ExitCode != 0:
+			res.Verdict = VerdictRuntimeError
+		case !Compare(t.Compare, t.Tolerance, t.ExpectedOutput, o.Stdout):
+			res.Verdict = VerdictWrongAnswer
+		default:
+			res.Verdict = VerdictAccepted
+			res.Passed = true
+			report.Passed++
+		}
+
+		if !res.Passed && report.Verdict == VerdictAccepted {
+			report.Verdict = res.Verdict
+		}
+		report.MaxTimeMs = max(report.MaxTimeMs, res.TimeMs)
+		report.MaxMemoryKB = max(report.MaxMemoryKB, res.MemoryKB)
+		report.Cases = append(report.Cases, res)
+	}
+	return report
+}
+
+func Compare(mode string, tolerance float64, expected, actual string) bool {
+	switch mode {
+	case CompareWhitespace:
+		return slicesEqual(strings.Fields(expected), strings.Fields(actual), func(a, b string) bool { return a == b })
+	case CompareFloat:
+		if tolerance <= 0 {
+			tolerance = DefaultTolerance
+		}
+		return slicesEqual(strings.Fields(expected), strings.Fields(actual), func(a, b string) bool {
+			return floatEqual(a, b, tolerance)
+		})
+	}
+	return trimNewline(expected) == trimNewline(actual)
+}
+
+func floatEqual(a, b string, tolerance float64) bool {
+	if a == b {
+		return true
+	}
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return false
+	}
+	diff := math.Abs(x - y)
+	return diff <= tolerance || diff <= tolerance*math.Max(math.Abs(x), math.Abs(y))
+}
+
+func slicesEqual(a, b []string, eq func(string, string) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func trimNewline(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
+
+func truncate(s string) string {
+	if len(s) <= maxReportOutput {
+		return s
+	}
+	return s[:maxReportOutput] + "\n... (output dipotong)\n"
+}
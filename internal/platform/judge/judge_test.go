@@ -0,0 +1,162 @@
+package judge
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		tolerance float64
+		expected  string
+		actual    string
+		want      bool
+	}{
+		{"exact match", CompareExact, 0, "hello", "hello", true},
+		{"exact default mode", "", 0, "hello", "hello", true},
+		{"exact ignores one trailing newline", CompareExact, 0, "42", "42\n", true},
+		{"exact keeps second trailing newline", CompareExact, 0, "42", "42\n\n", false},
+		{"exact rejects extra space", CompareExact, 0, "1 2", "1  2", false},
+		{"exact rejects case change", CompareExact, 0, "Yes", "yes", false},
+		{"exact trailing CRLF", CompareExact, 0, "42", "42\r\n", true},
+		{"exact CRLF between lines", CompareExact, 0, "1\n2", "1\r\n2\r\n", false},
+		{"exact empty output", CompareExact, 0, "", "", true},
+		{"exact empty vs newline", CompareExact, 0, "", "\n", true},
+		{"exact missing output", CompareExact, 0, "0", "", false},
+		{"whitespace collapses spacing", CompareWhitespace, 0, "1 2\n3", "1   2 3\n\n", true},
+		{"whitespace CRLF", CompareWhitespace, 0, "1\n2\n", "1\r\n2\r\n", true},
+		{"whitespace token differs", CompareWhitespace, 0, "1 2 3", "1 2 4", false},
+		{"whitespace extra token", CompareWhitespace, 0, "1 2", "1 2 3", false},
+		{"whitespace empty output", CompareWhitespace, 0, "", " \n\t", true},
+		{"whitespace missing output", CompareWhitespace, 0, "1", "", false},
+		{"float within default tolerance", CompareFloat, 0, "0.333333", "0.3333333", true},
+		{"float outside default tolerance", CompareFloat, 0, "0.333", "0.334", false},
+		{"float custom tolerance", CompareFloat, 1e-2, "0.333", "0.334", true},
+		{"float relative tolerance", CompareFloat, 1e-6, "1000000", "1000000.5", true},
+		{"float non numeric tokens exact", CompareFloat, 0, "YES 1.0", "YES 1", true},
+		{"float non numeric mismatch", CompareFloat, 0, "YES 1.0", "NO 1.0", false},
+		{"float token count differs", CompareFloat, 0, "1.0 2.0", "1.0", false},
+		{"float CRLF", CompareFloat, 0, "0.5\n", "0.5\r\n", true},
+		{"float empty output", CompareFloat, 0, "", "", true},
+		{"float rejects NaN", CompareFloat, 0, "1.0", "NaN", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(tt.mode, tt.tolerance, tt.expected, tt.actual); got != tt.want {
+				t.Fatalf("Compare(%q, %v, %q, %q) = %v, want %v", tt.mode, tt.tolerance, tt.expected, tt.actual, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateVerdicts(t *testing.T) {
+	test := TestCase{ExpectedOutput: "42", TimeLimitMs: 1000}
+	tests := []struct {
+		name    string
+		outcome Outcome
+		want    string
+	}{
+		{"accepted", Outcome{Stdout: "42\n", Ran: true, TimeMs: 10}, VerdictAccepted},
+		{"wrong answer", Outcome{Stdout: "41\n", Ran: true}, VerdictWrongAnswer},
+		{"runtime error beats wrong answer", Outcome{Stdout: "41\n", ExitCode: 1, Ran: true}, VerdictRuntimeError},
+		{"runtime error with right output", Outcome{Stdout: "42\n", ExitCode: 139, Ran: true}, VerdictRuntimeError},
+		{"time limit beats runtime error", Outcome{ExitCode: 137, TimedOut: true, Ran: true}, VerdictTimeLimit},
+		{"measured time over limit", Outcome{Stdout: "42\n", TimeMs: 1001, Ran: true}, VerdictTimeLimit},
+		{"measured time at limit", Outcome{Stdout: "42\n", TimeMs: 1000, Ran: true}, VerdictAccepted},
+		{"memory limit beats time limit", Outcome{ExitCode: 137, TimedOut: true, OOMKilled: true, Ran: true}, VerdictMemoryLimit},
+		{"memory limit beats runtime error", Outcome{ExitCode: 137, OOMKilled: true, Ran: true}, VerdictMemoryLimit},
+		{"not run", Outcome{Stdout: "42\n"}, VerdictSkipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Evaluate([]TestCase{test}, []Outcome{tt.outcome})
+			if got := report.Cases[0].Verdict; got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+			if report.Verdict != tt.want {
+				t.Fatalf("report verdict %s, want %s", report.Verdict, tt.want)
+			}
+			wantPassed := 0
+			if tt.want == VerdictAccepted {
+				wantPassed = 1
+			}
+			if report.Passed != wantPassed {
+				t.Fatalf("passed %d, want %d", report.Passed, wantPassed)
+			}
+		})
+	}
+}
+
+func TestEvaluateReport(t *testing.T) {
+	tests := []TestCase{
+		{ExpectedOutput: "1"},
+		{ExpectedOutput: "2"},
+		{ExpectedOutput: "3"},
+		{ExpectedOutput: "4"},
+	}
+	outcomes := []Outcome{
+		{Stdout: "1", Ran: true, TimeMs: 5, MemoryKB: 900},
+		{Stdout: "x", Ran: true, TimeMs: 7, MemoryKB: 1200},
+		{ExitCode: 1, Ran: true, TimeMs: 3, MemoryKB: 800},
+	}
+
+	report := Evaluate(tests, outcomes)
+	if report.Verdict != VerdictWrongAnswer {
+		t.Fatalf("verdict %s, want first failure %s", report.Verdict, VerdictWrongAnswer)
+	}
+	if report.Passed != 1 || report.Total != 4 {
+		t.Fatalf("passed %d/%d, want 1/4", report.Passed, report.Total)
+	}
+	if report.MaxTimeMs != 7 || report.MaxMemoryKB != 1200 {
+		t.Fatalf("max time %d memory %d, want 7 and 1200", report.MaxTimeMs, report.MaxMemoryKB)
+	}
+	want := []string{VerdictAccepted, VerdictWrongAnswer, VerdictRuntimeError, VerdictSkipped}
+	for i, c := range report.Cases {
+		if c.Index != i || c.Verdict != want[i] {
+			t.Fatalf("case %d: got index %d verdict %s, want %s", i, c.Index, c.Verdict, want[i])
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		tests []TestCase
+		ok    bool
+	}{
+		{"empty", nil, true},
+		{"known modes", []TestCase{{Compare: CompareExact}, {Compare: CompareWhitespace}, {Compare: CompareFloat, Tolerance: 1e-3}}, true},
+		{"unknown mode", []TestCase{{Compare: "regex"}}, false},
+		{"negative tolerance", []TestCase{{Compare: CompareFloat, Tolerance: -1}}, false},
+		{"negative time limit", []TestCase{{TimeLimitMs: -1}}, false},
+		{"too many tests", make([]TestCase, MaxTests+1), false},
+		{"input too large", []TestCase{{Input: string(make([]byte, MaxInputBytes+1))}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.tests)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidTests) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidTests)
+			}
+		})
+	}
+}
+
+func TestBudget(t *testing.T) {
+	tests := []TestCase{{TimeLimitMs: 500}, {}}
+	if got, want := TotalTimeLimit(tests), 500*time.Millisecond+DefaultTimeLimit; got != want {
+		t.Fatalf("TotalTimeLimit = %s, want %s", got, want)
+	}
+	if got, want := Budget(tests), TotalTimeLimit(tests)+2*CaseOverhead; got != want {
+		t.Fatalf("Budget = %s, want %s", got, want)
+	}
+}
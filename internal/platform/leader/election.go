@@ -0,0 +1,217 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+const DefaultLease = 15 * time.Second
+
+var ErrNotLeader = errors.New("instance ini bukan leader")
+
+var (
+	isLeader = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nebula_leader",
+		Help: "1 jika instance ini leader untuk election tersebut",
+	}, []string{"election", "instance"})
+
+	leaderToken = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nebula_leader_token",
+		Help: "Fencing token leadership yang sedang dipegang",
+	}, []string{"election"})
+
+	leaderTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nebula_leader_transitions_total",
+		Help: "Total perubahan status leadership instance ini",
+	}, []string{"election"})
+)
+
+var acquireScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+local token = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[1], ARGV[1] .. '|' .. token, 'PX', ARGV[2])
+return token
+`)
+
+var renewScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type Elector struct {
+	client   *redis.Client
+	name     string
+	key      string
+	tokenKey string
+	id       string
+	lease    time.Duration
+
+	mu       sync.RWMutex
+	token    int64
+	cancel   context.CancelFunc
+	handlers []func(ctx context.Context, token int64)
+}
+
+func NewElector(addr, name string, lease time.Duration) *Elector {
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+	hostname, _ := os.Hostname()
+	return &Elector{
+		client:   redis.NewClient(&redis.Options{Addr: addr}),
+		name:     name,
+		key:      fmt.Sprintf("nebula:leader:%s", name),
+		tokenKey: fmt.Sprintf("nebula:leader:%s:token", name),
+		id:       fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8]),
+		lease:    lease,
+	}
+}
+
+func (e *Elector) ID() string {
+	return e.id
+}
+
+func (e *Elector) OnElected(fn func(ctx context.Context, token int64)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.handlers = append(e.handlers, fn)
+}
+
+func (e *Elector) IsLeader() (bool, int64) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.token > 0, e.token
+}
+
+func (e *Elector) Leader(ctx context.Context) (string, int64, error) {
+	value, err := e.client.Get(ctx, e.key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	id, token := parseValue(value)
+	return id, token, nil
+}
+
+func (e *Elector) CheckToken(ctx context.Context, token int64) error {
+	id, current, err := e.Leader(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal cek leadership: %w", err)
+	}
+	if id != e.id || current != token {
+		return ErrNotLeader
+	}
+	return nil
+}
+
+func (e *Elector) Run(ctx context.Context) {
+	isLeader.WithLabelValues(e.name, e.id).Set(0)
+	ticker := time.NewTicker(e.lease / 3)
+	defer ticker.Stop()
+
+	for {
+		e.tick(ctx)
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) tick(ctx context.Context) {
+	leading, token := e.IsLeader()
+	if leading {
+		ok, err := renewScript.Run(ctx, e.client, []string{e.key}, e.value(token), e.lease.Milliseconds()).Int()
+		if err != nil || ok == 0 {
+			fmt.Printf("👋 [Leader] %s kehilangan leadership %s (err=%v)\n", e.id, e.name, err)
+			e.step(0)
+		}
+		return
+	}
+
+	token, err := acquireScript.Run(ctx, e.client, []string{e.key, e.tokenKey}, e.id, e.lease.Milliseconds()).Int64()
+	if err != nil {
+		fmt.Printf("⚠️ [Leader] Gagal ikut election %s: %v\n", e.name, err)
+		return
+	}
+	if token > 0 {
+		fmt.Printf("👑 [Leader] %s jadi leader %s (token %d)\n", e.id, e.name, token)
+		e.step(token)
+	}
+}
+
+func (e *Elector) step(token int64) {
+	e.mu.Lock()
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+	e.token = token
+	var ctx context.Context
+	if token > 0 {
+		ctx, e.cancel = context.WithCancel(context.Background())
+	}
+	handlers := append([]func(context.Context, int64){}, e.handlers...)
+	e.mu.Unlock()
+
+	leaderTransitions.WithLabelValues(e.name).Inc()
+	leaderToken.WithLabelValues(e.name).Set(float64(token))
+	if token == 0 {
+		isLeader.WithLabelValues(e.name, e.id).Set(0)
+		return
+	}
+	isLeader.WithLabelValues(e.name, e.id).Set(1)
+	for _, fn := range handlers {
+		go fn(ctx, token)
+	}
+}
+
+func (e *Elector) resign() {
+	leading, token := e.IsLeader()
+	if !leading {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	releaseScript.Run(ctx, e.client, []string{e.key}, e.value(token))
+	e.step(0)
+}
+
+func (e *Elector) value(token int64) string {
+	return fmt.Sprintf("%s|%d", e.id, token)
+}
+
+func parseValue(value string) (string, int64) {
+	i := strings.LastIndex(value, "|")
+	if i < 0 {
+		return value, 0
+	}
+	token, _ := strconv.ParseInt(value[i+1:], 10, 64)
+	return value[:i], token
+}
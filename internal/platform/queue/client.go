@@ -3,59 +3,395 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/JullMol/nebula/internal/platform/judge"
+	"github.com/JullMol/nebula/internal/platform/runtimes"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+const DefaultVisibilityTimeout = 2 * time.Minute
+
 type Job struct {
-	ID      string `json:"id"`
-	Image   string `json:"image"`
-	Command string `json:"command"`
-	Code    string `json:"code"`
+	ID             string            `json:"id"`
+	Image          string            `json:"image"`
+	Runtime        runtimes.Runtime  `json:"runtime"`
+	Command        string            `json:"command"`
+	Code           string            `json:"code"`
+	Archive        []byte            `json:"archive,omitempty"`
+	Entrypoint     string            `json:"entrypoint,omitempty"`
+	Stdin          string            `json:"stdin,omitempty"`
+	Tests          []judge.TestCase  `json:"tests,omitempty"`
+	TimeoutSeconds int               `json:"timeout_seconds"`
+	Limits         ResourceLimits    `json:"limits"`
+	SandboxProfile string            `json:"sandbox_profile"`
+	Retry          RetryPolicy       `json:"retry"`
+	NodeSelector   map[string]string `json:"node_selector,omitempty"`
+	AntiAffinity   map[string]string `json:"anti_affinity,omitempty"`
+
+	MessageID string `json:"-"`
+}
+
+type ResourceLimits struct {
+	MemoryMB  int64   `json:"memory_mb,omitempty"`
+	CPUs      float64 `json:"cpus,omitempty"`
+	PidsLimit int64   `json:"pids_limit,omitempty"`
+	TmpfsMB   int64   `json:"tmpfs_mb,omitempty"`
+}
+
+type RetryPolicy struct {
+	MaxAttempts    int           `json:"max_attempts"`
+	InitialBackoff time.Duration `json:"initial_backoff"`
+	MaxBackoff     time.Duration `json:"max_backoff"`
+	Multiplier     float64       `json:"multiplier"`
+	RetryableCodes []string      `json:"retryable_codes"`
 }
 
+type DeadLetter struct {
+	Job      Job       `json:"job"`
+	Reason   string    `json:"reason"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+var ErrNotFound = errors.New("job tidak ada di queue")
+
 type QueueSystem interface {
 	Enqueue(ctx context.Context, job Job) error
 	Dequeue(ctx context.Context) (*Job, error)
+	Ack(ctx context.Context, job *Job) error
+	Nack(ctx context.Context, job *Job) error
+	Extend(ctx context.Context, job *Job) error
+	Retry(ctx context.Context, job *Job, delay time.Duration) error
+	DeadLetter(ctx context.Context, job *Job, reason string) error
+	DeadLetters(ctx context.Context) ([]DeadLetter, error)
+	Redrive(ctx context.Context, jobID string) (*Job, error)
+	Remove(ctx context.Context, jobID string) error
+	VisibilityTimeout() time.Duration
 	SetResult(ctx context.Context, jobID string, result string) error
 	GetResult(ctx context.Context, jobID string) (string, error)
 }
 
 type RedisQueue struct {
-	client *redis.Client
-	queueName string
+	client            *redis.Client
+	queueName         string
+	delayedName       string
+	payloadsName      string
+	indexName         string
+	deadName          string
+	groupName         string
+	consumerName      string
+	visibilityTimeout time.Duration
+
+	groupMu    sync.Mutex
+	groupReady bool
 }
 
-func NewRedisQueue(addr string) *RedisQueue {
+func NewRedisQueue(addr string, visibilityTimeout time.Duration) *RedisQueue {
 	rdb := redis.NewClient(&redis.Options{
 		Addr: addr,
 	})
+	if visibilityTimeout <= 0 {
+		visibilityTimeout = DefaultVisibilityTimeout
+	}
+	hostname, _ := os.Hostname()
 	return &RedisQueue{
-		client: rdb,
-		queueName: "nebula_jobs",
+		client:            rdb,
+		queueName:         "nebula:jobs",
+		delayedName:       "nebula:jobs:delayed",
+		payloadsName:      "nebula:jobs:delayed:payloads",
+		indexName:         "nebula:jobs:index",
+		deadName:          "nebula:jobs:dead",
+		groupName:         "nebula-dispatchers",
+		consumerName:      fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8]),
+		visibilityTimeout: visibilityTimeout,
 	}
 }
 
+func (r *RedisQueue) ensureGroup(ctx context.Context) error {
+	r.groupMu.Lock()
+	defer r.groupMu.Unlock()
+	if r.groupReady {
+		return nil
+	}
+	err := r.client.XGroupCreateMkStream(ctx, r.queueName, r.groupName, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("gagal bikin consumer group: %w", err)
+	}
+	r.groupReady = true
+	return nil
+}
+
+var addScript = redis.NewScript(`
+local id = redis.call('XADD', KEYS[1], '*', 'job', ARGV[2])
+redis.call('HSET', KEYS[2], ARGV[1], id)
+return id
+`)
+
+func (r *RedisQueue) add(ctx context.Context, s redis.Scripter, jobID string, data []byte) *redis.Cmd {
+	return addScript.Eval(ctx, s, []string{r.queueName, r.indexName}, jobID, data)
+}
+
 func (r *RedisQueue) Enqueue(ctx context.Context, job Job) error {
 	data, _ := json.Marshal(job)
-	return r.client.RPush(ctx, r.queueName, data).Err()
+	return r.add(ctx, r.client, job.ID, data).Err()
 }
 
 func (r *RedisQueue) Dequeue(ctx context.Context) (*Job, error) {
-	val, err := r.client.BLPop(ctx, 0*time.Second, r.queueName).Result()
-	if err != nil {
+	if err := r.ensureGroup(ctx); err != nil {
 		return nil, err
 	}
 
+	for {
+		if err := r.promoteDelayed(ctx); err != nil {
+			return nil, err
+		}
+
+		claimed, _, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   r.queueName,
+			Group:    r.groupName,
+			Consumer: r.consumerName,
+			MinIdle:  r.visibilityTimeout,
+			Start:    "0-0",
+			Count:    1,
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(claimed) > 0 {
+			fmt.Printf("♻️ [Queue] Re-delivering message %s (consumer sebelumnya timeout)\n", claimed[0].ID)
+			if job, ok := r.decode(ctx, claimed[0]); ok {
+				return job, nil
+			}
+			continue
+		}
+
+		streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    r.groupName,
+			Consumer: r.consumerName,
+			Streams:  []string{r.queueName, ">"},
+			Count:    1,
+			Block:    2 * time.Second,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(streams) == 0 || len(streams[0].Messages) == 0 {
+			continue
+		}
+		if job, ok := r.decode(ctx, streams[0].Messages[0]); ok {
+			return job, nil
+		}
+	}
+}
+
+func (r *RedisQueue) decode(ctx context.Context, msg redis.XMessage) (*Job, bool) {
+	job, err := decodeMessage(msg)
+	if err == nil {
+		return job, true
+	}
+
+	fmt.Printf("🗑️ [Queue] Message %s rusak, dibuang: %v\n", msg.ID, err)
+	_, pErr := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		r.release(ctx, pipe, &Job{MessageID: msg.ID})
+		return nil
+	})
+	if pErr != nil {
+		fmt.Printf("⚠️ [Queue] Gagal buang message %s: %v\n", msg.ID, pErr)
+	}
+	return nil, false
+}
+
+func decodeMessage(msg redis.XMessage) (*Job, error) {
+	raw, ok := msg.Values["job"].(string)
+	if !ok {
+		return nil, fmt.Errorf("message %s tidak punya payload job", msg.ID)
+	}
+
 	var job Job
-	if err := json.Unmarshal([]byte(val[1]), &job); err != nil {
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
 		return nil, err
 	}
+	job.MessageID = msg.ID
 	return &job, nil
 }
 
+var promoteScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, member in ipairs(due) do
+	redis.call('ZREM', KEYS[1], member)
+	local payload = redis.call('HGET', KEYS[3], member)
+	if payload then
+		redis.call('HDEL', KEYS[3], member)
+		local id = redis.call('XADD', KEYS[2], '*', 'job', payload)
+		redis.call('HSET', KEYS[4], member, id)
+	else
+		redis.call('XADD', KEYS[2], '*', 'job', member)
+	end
+end
+return #due
+`)
+
+func (r *RedisQueue) promoteDelayed(ctx context.Context) error {
+	now := time.Now().UnixMilli()
+	return promoteScript.Run(ctx, r.client, []string{r.delayedName, r.queueName, r.payloadsName, r.indexName}, now).Err()
+}
+
+var unindexScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) == ARGV[2] then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+func (r *RedisQueue) release(ctx context.Context, pipe redis.Pipeliner, job *Job) {
+	if job.MessageID == "" {
+		return
+	}
+	pipe.XAck(ctx, r.queueName, r.groupName, job.MessageID)
+	pipe.XDel(ctx, r.queueName, job.MessageID)
+	if job.ID != "" {
+		unindexScript.Eval(ctx, pipe, []string{r.indexName}, job.ID, job.MessageID)
+	}
+}
+
+func (r *RedisQueue) Ack(ctx context.Context, job *Job) error {
+	if job.MessageID == "" {
+		return nil
+	}
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		r.release(ctx, pipe, job)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisQueue) Nack(ctx context.Context, job *Job) error {
+	return r.Retry(ctx, job, 0)
+}
+
+func (r *RedisQueue) Extend(ctx context.Context, job *Job) error {
+	if job.MessageID == "" {
+		return nil
+	}
+	return r.client.XClaimJustID(ctx, &redis.XClaimArgs{
+		Stream:   r.queueName,
+		Group:    r.groupName,
+		Consumer: r.consumerName,
+		MinIdle:  0,
+		Messages: []string{job.MessageID},
+	}).Err()
+}
+
+func (r *RedisQueue) Retry(ctx context.Context, job *Job, delay time.Duration) error {
+	data, _ := json.Marshal(job)
+	readyAt := time.Now().Add(delay).UnixMilli()
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, r.payloadsName, job.ID, data)
+		pipe.ZAdd(ctx, r.delayedName, redis.Z{Score: float64(readyAt), Member: job.ID})
+		r.release(ctx, pipe, job)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisQueue) DeadLetter(ctx context.Context, job *Job, reason string) error {
+	data, _ := json.Marshal(DeadLetter{
+		Job:      *job,
+		Reason:   reason,
+		FailedAt: time.Now(),
+	})
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, r.deadName, job.ID, data)
+		r.release(ctx, pipe, job)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisQueue) DeadLetters(ctx context.Context) ([]DeadLetter, error) {
+	vals, err := r.client.HVals(ctx, r.deadName).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]DeadLetter, 0, len(vals))
+	for _, v := range vals {
+		var dl DeadLetter
+		if err := json.Unmarshal([]byte(v), &dl); err != nil {
+			continue
+		}
+		letters = append(letters, dl)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+	return letters, nil
+}
+
+func (r *RedisQueue) Redrive(ctx context.Context, jobID string) (*Job, error) {
+	raw, err := r.client.HGet(ctx, r.deadName, jobID).Result()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dl DeadLetter
+	if err := json.Unmarshal([]byte(raw), &dl); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(dl.Job)
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, r.deadName, jobID)
+		r.add(ctx, pipe, jobID, data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dl.Job, nil
+}
+
+var removeScript = redis.NewScript(`
+local removed = redis.call('ZREM', KEYS[3], ARGV[1])
+redis.call('HDEL', KEYS[4], ARGV[1])
+local id = redis.call('HGET', KEYS[1], ARGV[1])
+if id then
+	redis.call('XACK', KEYS[2], ARGV[2], id)
+	removed = removed + redis.call('XDEL', KEYS[2], id)
+	redis.call('HDEL', KEYS[1], ARGV[1])
+end
+return removed
+`)
+
+func (r *RedisQueue) Remove(ctx context.Context, jobID string) error {
+	keys := []string{r.indexName, r.queueName, r.delayedName, r.payloadsName}
+	removed, err := removeScript.Run(ctx, r.client, keys, jobID, r.groupName).Int()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *RedisQueue) VisibilityTimeout() time.Duration {
+	return r.visibilityTimeout
+}
+
 func (r *RedisQueue) SetResult(ctx context.Context, jobID string, result string) error {
 	key := fmt.Sprintf("result:%s", jobID)
 	return r.client.Set(ctx, key, result, 10*time.Minute).Err()
@@ -71,4 +407,4 @@ func (r *RedisQueue) GetResult(ctx context.Context, jobID string) (string, error
 		return "", err
 	}
 	return val, nil
-}
\ No newline at end of file
+}
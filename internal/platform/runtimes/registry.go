@@ -0,0 +1,132 @@
+package runtimes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JullMol/nebula/pkg/config"
+)
+
+const (
+	SourceDir       = "/app"
+	FilePlaceholder = "{file}"
+
+	DefaultCompileTimeout = 30 * time.Second
+)
+
+var (
+	ErrNoLanguage      = errors.New("language wajib diisi")
+	ErrUnknownLanguage = errors.New("language tidak didukung")
+	ErrUnknownVersion  = errors.New("version tidak didukung")
+)
+
+type Runtime struct {
+	Language       string        `json:"language"`
+	Version        string        `json:"version"`
+	Image          string        `json:"image"`
+	FileName       string        `json:"file_name"`
+	CompileCommand string        `json:"compile_command,omitempty"`
+	CompileTimeout time.Duration `json:"compile_timeout,omitempty"`
+	RunCommand     string        `json:"run_command"`
+	Default        bool          `json:"default"`
+}
+
+func (rt Runtime) Compiled() bool {
+	return rt.CompileCommand != ""
+}
+
+type Registry struct {
+	runtimes map[string][]Runtime
+}
+
+func NewRegistry(cfgs []config.RuntimeConfig) (*Registry, error) {
+	r := &Registry{runtimes: make(map[string][]Runtime)}
+	for _, c := range cfgs {
+		rt := Runtime{
+			Language:       strings.ToLower(strings.TrimSpace(c.Language)),
+			Version:        strings.TrimSpace(c.Version),
+			Image:          c.Image,
+			FileName:       c.FileName,
+			CompileCommand: c.CompileCommand,
+			CompileTimeout: c.CompileTimeout,
+			RunCommand:     c.RunCommand,
+			Default:        c.Default,
+		}
+		if rt.Compiled() && rt.CompileTimeout <= 0 {
+			rt.CompileTimeout = DefaultCompileTimeout
+		}
+		if err := validate(rt); err != nil {
+			return nil, err
+		}
+		if _, err := r.Resolve(rt.Language, rt.Version); err == nil {
+			return nil, fmt.Errorf("runtime %s %s didefinisikan lebih dari sekali", rt.Language, rt.Version)
+		}
+		r.runtimes[rt.Language] = append(r.runtimes[rt.Language], rt)
+	}
+	return r, nil
+}
+
+func validate(rt Runtime) error {
+	switch {
+	case rt.Language == "":
+		return fmt.Errorf("runtime tanpa language")
+	case rt.Version == "":
+		return fmt.Errorf("runtime %s tanpa version", rt.Language)
+	case rt.Image == "":
+		return fmt.Errorf("runtime %s %s tanpa image", rt.Language, rt.Version)
+	case rt.FileName == "" || path.Base(rt.FileName) != rt.FileName:
+		return fmt.Errorf("runtime %s %s: file_name %q tidak valid", rt.Language, rt.Version, rt.FileName)
+	case rt.RunCommand == "":
+		return fmt.Errorf("runtime %s %s tanpa run_command", rt.Language, rt.Version)
+	}
+	return nil
+}
+
+func (r *Registry) Resolve(language, version string) (Runtime, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		return Runtime{}, ErrNoLanguage
+	}
+	versions, ok := r.runtimes[language]
+	if !ok {
+		return Runtime{}, fmt.Errorf("%w: %s", ErrUnknownLanguage, language)
+	}
+
+	version = strings.TrimSpace(version)
+	if version == "" {
+		for _, rt := range versions {
+			if rt.Default {
+				return rt, nil
+			}
+		}
+		return versions[0], nil
+	}
+	for _, rt := range versions {
+		if rt.Version == version {
+			return rt, nil
+		}
+	}
+	return Runtime{}, fmt.Errorf("%w: %s %s", ErrUnknownVersion, language, version)
+}
+
+func (r *Registry) List() []Runtime {
+	languages := make([]string, 0, len(r.runtimes))
+	for language := range r.runtimes {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	var list []Runtime
+	for _, language := range languages {
+		list = append(list, r.runtimes[language]...)
+	}
+	return list
+}
+
+func Expand(command, fileName string) string {
+	return strings.ReplaceAll(command, FilePlaceholder, path.Join(SourceDir, fileName))
+}
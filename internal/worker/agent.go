@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/pkg/config"
+)
+
+const defaultHeartbeatInterval = 5 * time.Second
+
+type Agent struct {
+	cfg     config.WorkerConfig
+	address string
+	server  *Server
+}
+
+func NewAgent(cfg config.WorkerConfig, address string, server *Server) *Agent {
+	return &Agent{
+		cfg:     cfg,
+		address: address,
+		server:  server,
+	}
+}
+
+func (a *Agent) Run(ctx context.Context) {
+	conn, err := grpc.NewClient(a.cfg.ControlPlaneAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Printf("❌ Gagal connect ke control plane %s: %v\n", a.cfg.ControlPlaneAddr, err)
+		return
+	}
+	defer conn.Close()
+
+	client := pb.NewControlPlaneClient(conn)
+	interval := defaultHeartbeatInterval
+	registered := false
+
+	for {
+		if !registered {
+			totalMemoryMB, totalCPUs := a.server.Capacity()
+			resp, err := client.RegisterWorker(ctx, &pb.RegisterWorkerRequest{
+				Name:          a.cfg.Name,
+				Address:       a.address,
+				Capacity:      int32(a.cfg.Capacity),
+				Labels:        a.cfg.Labels,
+				Weight:        int32(a.cfg.Weight),
+				TotalMemoryMb: totalMemoryMB,
+				TotalCpus:     totalCPUs,
+				DefaultLimits: &pb.ResourceLimits{
+					MemoryMb: a.cfg.DefaultLimits.MemoryMB,
+					Cpus:     a.cfg.DefaultLimits.CPUs,
+				},
+			})
+			if err != nil {
+				fmt.Printf("⚠️ Registrasi ke control plane gagal: %v\n", err)
+			} else {
+				registered = true
+				if resp.HeartbeatIntervalSeconds > 0 {
+					interval = time.Duration(resp.HeartbeatIntervalSeconds) * time.Second
+				}
+				fmt.Printf("🛰️ Terdaftar di control plane %s sebagai %s\n", a.cfg.ControlPlaneAddr, a.address)
+			}
+		} else {
+			freeMemoryMB, freeCPUs := a.server.Free()
+			resp, err := client.Heartbeat(ctx, &pb.HeartbeatRequest{
+				Address:           a.address,
+				RunningContainers: int32(a.server.Running()),
+				FreeMemoryMb:      freeMemoryMB,
+				FreeCpus:          freeCPUs,
+				Images:            a.server.CachedImages(ctx),
+			})
+			if err != nil {
+				fmt.Printf("⚠️ Heartbeat gagal: %v\n", err)
+			} else if !resp.Known {
+				fmt.Println("🔁 Control plane tidak mengenali worker ini, registrasi ulang...")
+				registered = false
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/docker"
+	"github.com/JullMol/nebula/internal/platform/runtimes"
+	"github.com/JullMol/nebula/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxCompileOutput = 64 * 1024
+
+func (s *Server) compile(ctx context.Context, req *pb.StartContainerRequest, fileName string, files []byte, sandbox docker.SandboxProfile) (string, *pb.CompileResult, error) {
+	rt := req.Runtime
+	if len(files) == 0 {
+		return "", nil, status.Errorf(codes.InvalidArgument, "runtime %s %s butuh code untuk dikompilasi", rt.Language, rt.Version)
+	}
+
+	limits, err := resolveLimits(nil, s.cfg.CompileLimits, config.ResourceLimits{})
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	timeout := time.Duration(rt.CompileTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = runtimes.DefaultCompileTimeout
+	}
+
+	vol, err := s.dockerClient.CreateVolume(ctx, req.JobId)
+	if err != nil {
+		return "", nil, dockerStatus(err)
+	}
+
+	s.running.Add(1)
+	defer s.running.Add(-1)
+
+	fmt.Printf("🔨 Compile job %s (%s %s), batas waktu %s\n", req.JobId, rt.Language, rt.Version, timeout)
+	started := time.Now()
+	containerID, err := s.dockerClient.RunContainer(ctx, docker.ContainerSpec{
+		JobID:   req.JobId,
+		Image:   req.Image,
+		Command: runtimes.Expand(rt.CompileCommand, fileName),
+		Files:   files,
+		Volume:  vol,
+		Limits:  limits,
+		Sandbox: sandbox,
+	})
+	if err != nil {
+		s.dockerClient.RemoveVolume(context.Background(), vol)
+		return "", nil, dockerStatus(err)
+	}
+	discard := func() {
+		s.dockerClient.RemoveContainer(context.Background(), containerID, true)
+		s.dockerClient.RemoveVolume(context.Background(), vol)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := &pb.CompileResult{}
+	if err := s.dockerClient.WaitContainer(waitCtx, containerID); err != nil {
+		if waitCtx.Err() != context.DeadlineExceeded {
+			discard()
+			return "", nil, err
+		}
+		result.TimedOut = true
+		if err := s.dockerClient.KillContainer(context.Background(), containerID); err != nil {
+			fmt.Printf("⚠️ Gagal kill container compile %s: %v\n", containerID, err)
+		}
+	}
+	result.DurationMs = time.Since(started).Milliseconds()
+
+	if state, err := s.dockerClient.InspectState(context.Background(), containerID); err == nil {
+		result.ExitCode = int32(state.ExitCode)
+	}
+	if logs, err := s.dockerClient.GetLogs(context.Background(), containerID); err == nil {
+		result.Output = truncateOutput(logs.Combined)
+	}
+	if result.TimedOut {
+		result.Output += fmt.Sprintf("\nKompilasi melewati batas waktu %s\n", timeout)
+	}
+
+	result.Success = !result.TimedOut && result.ExitCode == 0
+	if !result.Success {
+		fmt.Printf("🧱 Compile job %s gagal (exit %d)\n", req.JobId, result.ExitCode)
+		discard()
+		return "", result, nil
+	}
+	s.dockerClient.RemoveContainer(context.Background(), containerID, true)
+	return vol, result, nil
+}
+
+func truncateOutput(out string) string {
+	if len(out) <= maxCompileOutput {
+		return out
+	}
+	return out[:maxCompileOutput] + "\n... (output dipotong)\n"
+}
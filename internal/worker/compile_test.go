@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/docker"
+	"github.com/JullMol/nebula/pkg/config"
+)
+
+func TestCompileHardenedWritesBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("butuh Docker")
+	}
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		t.Skipf("Docker tidak tersedia: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := dockerClient.Ping(ctx); err != nil {
+		t.Skipf("Docker tidak tersedia: %v", err)
+	}
+
+	limits := config.ResourceLimits{MemoryMB: 128, CPUs: 0.5, PidsLimit: 64, TmpfsMB: 16}
+	s, err := NewServer(dockerClient, config.WorkerConfig{
+		TotalMemoryMB: 1024,
+		TotalCPUs:     2,
+		DefaultLimits: limits,
+		Sandbox:       config.SandboxConfig{DefaultProfile: docker.ProfileHardened, User: "65534:65534"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.StartContainer(ctx, &pb.StartContainerRequest{
+		JobId: "compile-hardened-test",
+		Image: "busybox:latest",
+		Code:  "echo built-and-ran",
+		Runtime: &pb.Runtime{
+			FileName:       "main.sh",
+			CompileCommand: "cp {file} /app/main && chmod 755 /app/main",
+			RunCommand:     "sh /app/main",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Compile.GetSuccess() {
+		t.Fatalf("compile gagal (exit %d): %s", resp.Compile.GetExitCode(), resp.Compile.GetOutput())
+	}
+	defer dockerClient.RemoveJob(context.Background(), resp.ContainerId)
+
+	if err := dockerClient.WaitContainer(ctx, resp.ContainerId); err != nil {
+		t.Fatal(err)
+	}
+	logs, err := dockerClient.GetLogs(ctx, resp.ContainerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.Stdout, "built-and-ran") {
+		t.Fatalf("stdout %q, stderr %q", logs.Stdout, logs.Stderr)
+	}
+}
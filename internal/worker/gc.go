@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+const (
+	defaultRetention     = 15 * time.Minute
+	defaultSweepInterval = 5 * time.Minute
+	defaultMaxAge        = time.Hour
+)
+
+func (s *Server) scheduleRemoval(containerID string) {
+	time.AfterFunc(s.cfg.GC.Retention, func() {
+		s.removeJob(containerID)
+	})
+}
+
+func (s *Server) removeJob(containerID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.dockerClient.RemoveJob(ctx, containerID); err != nil && !errdefs.IsNotFound(err) {
+		fmt.Printf("⚠️ Gagal hapus container %s: %v\n", containerID, err)
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.timedOut, containerID)
+	s.mu.Unlock()
+}
+
+func (s *Server) RunSweeper(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.GC.SweepInterval)
+	defer ticker.Stop()
+	for {
+		s.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) sweep(ctx context.Context) {
+	containers, err := s.dockerClient.ListManaged(ctx)
+	if err != nil {
+		fmt.Printf("⚠️ [GC] Gagal list container: %v\n", err)
+		return
+	}
+
+	cutoff := time.Now().Add(-s.cfg.GC.MaxAge)
+	inUse := make(map[string]bool)
+	removed := 0
+	for _, ctr := range containers {
+		if ctr.Created.Before(cutoff) {
+			s.removeJob(ctr.ID)
+			removed++
+			continue
+		}
+		if ctr.Volume != "" {
+			inUse[ctr.Volume] = true
+		}
+	}
+
+	volumes, err := s.dockerClient.ListManagedVolumes(ctx)
+	if err != nil {
+		fmt.Printf("⚠️ [GC] Gagal list volume: %v\n", err)
+	}
+	for _, vol := range volumes {
+		if inUse[vol.Name] || vol.Created.After(cutoff) {
+			continue
+		}
+		if err := s.dockerClient.RemoveVolume(ctx, vol.Name); err != nil {
+			continue
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		fmt.Printf("🧹 [GC] %d container/volume lama dibersihkan\n", removed)
+	}
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/JullMol/nebula/internal/platform/docker"
+)
+
+const dockerPingInterval = 5 * time.Second
+
+func WatchDocker(ctx context.Context, dockerClient *docker.Client, hs *health.Server) {
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := dockerClient.Ping(pingCtx)
+		cancel()
+
+		next := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			next = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if next != last {
+			fmt.Printf("🩺 Status kesehatan worker: %s\n", next)
+			hs.SetServingStatus("", next)
+			last = next
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dockerPingInterval):
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/docker"
+	"github.com/JullMol/nebula/internal/platform/judge"
+	"github.com/JullMol/nebula/internal/platform/runtimes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	inputDir      = ".nebula"
+	stdinRel      = inputDir + "/stdin"
+	testsRel      = inputDir + "/tests"
+	maxCaseOutput = 256 * 1024
+	memoryMarker  = "__nebula_memory__"
+)
+
+const caseWrapper = `sh -c %s < %s
+rc=$?
+kill -9 -1 2>/dev/null
+peak=$(cat /sys/fs/cgroup/memory.peak 2>/dev/null || cat /sys/fs/cgroup/memory/memory.max_usage_in_bytes 2>/dev/null || echo 0)
+printf '\n%s %%s\n' "$peak" >&2
+exit $rc`
+
+func wrapCommand(req *pb.StartContainerRequest, command string) string {
+	if req.Stdin != "" && len(req.Tests) == 0 {
+		return fmt.Sprintf("( %s ) < %s", command, path.Join(runtimes.SourceDir, stdinRel))
+	}
+	return command
+}
+
+func caseCommand(command string, index int) string {
+	input := path.Join(runtimes.SourceDir, testsRel, fmt.Sprintf("%d.in", index))
+	return fmt.Sprintf(caseWrapper, shellQuote(command), input, memoryMarker)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func writeInputs(tw *tar.Writer, req *pb.StartContainerRequest, now time.Time) error {
+	if req.Stdin == "" && len(req.Tests) == 0 {
+		return nil
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: inputDir + "/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: now}); err != nil {
+		return err
+	}
+	if req.Stdin != "" {
+		if err := writeFile(tw, stdinRel, req.Stdin, now); err != nil {
+			return err
+		}
+	}
+	if len(req.Tests) == 0 {
+		return nil
+	}
+
+	if err := tw.WriteHeader(&tar.Header{Name: testsRel + "/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: now}); err != nil {
+		return err
+	}
+	for i, t := range req.Tests {
+		if err := writeFile(tw, fmt.Sprintf("%s/%d.in", testsRel, i), t.Input, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(tw *tar.Writer, name, content string, now time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content)), ModTime: now}); err != nil {
+		return err
+	}
+	_, err := tw.Write([]byte(content))
+	return err
+}
+
+func (s *Server) runTests(ctx context.Context, req *pb.StartContainerRequest, spec docker.ContainerSpec, command string, timeout time.Duration) ([]*pb.TestOutcome, error) {
+	key := "judge:" + req.JobId
+	s.running.Add(1)
+	s.mu.Lock()
+	s.reserved[key] = spec.Limits
+	s.mu.Unlock()
+	defer s.release(key)
+
+	if spec.Volume == "" {
+		vol, err := s.dockerClient.CreateVolume(ctx, req.JobId)
+		if err != nil {
+			return nil, dockerStatus(err)
+		}
+		spec.Volume = vol
+		if err := s.dockerClient.FillVolume(ctx, spec); err != nil {
+			s.dockerClient.RemoveVolume(context.Background(), vol)
+			return nil, dockerStatus(err)
+		}
+	}
+	defer s.dockerClient.RemoveVolume(context.Background(), spec.Volume)
+
+	spec.Files = nil
+	spec.ReadOnly = true
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var outcomes []*pb.TestOutcome
+	for i, t := range req.Tests {
+		if err := ctx.Err(); errors.Is(err, context.Canceled) {
+			fmt.Printf("🛑 Job %s dibatalkan setelah %d/%d test\n", req.JobId, i, len(req.Tests))
+			return nil, status.Error(codes.Canceled, err.Error())
+		} else if err != nil {
+			fmt.Printf("⏰ Job %s melewati batas waktu %s setelah %d/%d test\n", req.JobId, timeout, i, len(req.Tests))
+			break
+		}
+		limit := time.Duration(t.TimeLimitMs) * time.Millisecond
+		if limit <= 0 {
+			limit = judge.DefaultTimeLimit
+		}
+
+		outcome, err := s.runCase(ctx, spec, command, i, limit)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+func (s *Server) runCase(ctx context.Context, spec docker.ContainerSpec, command string, index int, limit time.Duration) (*pb.TestOutcome, error) {
+	spec.Command = caseCommand(command, index)
+	containerID, err := s.dockerClient.RunContainer(ctx, spec)
+	if err != nil {
+		return nil, dockerStatus(err)
+	}
+	defer s.dockerClient.RemoveContainer(context.Background(), containerID, true)
+
+	outcome := &pb.TestOutcome{Index: int32(index)}
+	waitCtx, cancel := context.WithTimeout(ctx, limit+judge.CaseKillMargin)
+	defer cancel()
+	if err := s.dockerClient.WaitContainer(waitCtx, containerID); err != nil {
+		if waitCtx.Err() == nil {
+			return nil, dockerStatus(err)
+		}
+		if err := s.dockerClient.KillContainer(context.Background(), containerID); err != nil {
+			fmt.Printf("⚠️ Gagal kill container test %s: %v\n", containerID, err)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		outcome.TimedOut = true
+	}
+
+	state, err := s.dockerClient.InspectState(context.Background(), containerID)
+	if err != nil {
+		return nil, dockerStatus(err)
+	}
+	outcome.ExitCode = int32(state.ExitCode)
+	outcome.OomKilled = state.OOMKilled
+	startedAt, errStart := time.Parse(time.RFC3339Nano, state.StartedAt)
+	finishedAt, errFinish := time.Parse(time.RFC3339Nano, state.FinishedAt)
+	if errStart == nil && errFinish == nil {
+		elapsed := finishedAt.Sub(startedAt)
+		outcome.TimeMs = elapsed.Milliseconds()
+		if elapsed > limit {
+			outcome.TimedOut = true
+		}
+	}
+	if outcome.TimedOut {
+		outcome.TimeMs = max(outcome.TimeMs, limit.Milliseconds())
+	}
+
+	logs, err := s.dockerClient.GetLogsLimited(context.Background(), containerID, maxCaseOutput)
+	if err != nil {
+		return nil, dockerStatus(err)
+	}
+	outcome.Stdout = logs.Stdout
+	outcome.Stderr = stripMarker(logs.Stderr)
+	if !outcome.TimedOut && !outcome.OomKilled {
+		if line, err := s.dockerClient.LastStderrLine(context.Background(), containerID); err == nil {
+			outcome.MemoryKb = parseMemory(line)
+		}
+	}
+	return outcome, nil
+}
+
+func parseMemory(line string) int64 {
+	value, ok := strings.CutPrefix(strings.TrimSpace(line), memoryMarker+" ")
+	if !ok {
+		return 0
+	}
+	bytes, _ := strconv.ParseInt(value, 10, 64)
+	return bytes / 1024
+}
+
+func stripMarker(stderr string) string {
+	trimmed := strings.TrimSuffix(stderr, "\n")
+	i := strings.LastIndex(trimmed, "\n")
+	if !strings.HasPrefix(trimmed[i+1:], memoryMarker+" ") {
+		return stderr
+	}
+	return trimmed[:max(i, 0)]
+}
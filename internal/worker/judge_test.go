@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripMarker(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   string
+	}{
+		{"no program output", "\n__nebula_memory__ 2048\n", ""},
+		{"program output with newline", "oops\n\n__nebula_memory__ 2048\n", "oops\n"},
+		{"program output without newline", "oops\n__nebula_memory__ 2048\n", "oops"},
+		{"multi line output", "a\nb\n\n__nebula_memory__ 1\n", "a\nb\n"},
+		{"marker only", "__nebula_memory__ 2048\n", ""},
+		{"marker without trailing newline", "oops\n__nebula_memory__ 2048", "oops"},
+		{"no marker when killed", "oops\n", "oops\n"},
+		{"no marker without newline", "oops", "oops"},
+		{"empty", "", ""},
+		{"forged marker earlier", "__nebula_memory__ 1\nreal\n", "__nebula_memory__ 1\nreal\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripMarker(tt.stderr); got != tt.want {
+				t.Fatalf("stripMarker(%q) = %q, want %q", tt.stderr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		line string
+		want int64
+	}{
+		{"__nebula_memory__ 2097152\n", 2048},
+		{"__nebula_memory__ 1023", 0},
+		{"__nebula_memory__ 0", 0},
+		{"__nebula_memory__ max", 0},
+		{"__nebula_memory__", 0},
+		{"some program output", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseMemory(tt.line); got != tt.want {
+			t.Fatalf("parseMemory(%q) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestCaseCommandQuotesProgram(t *testing.T) {
+	got := caseCommand("echo 'hi'; python3 /app/main.py", 3)
+	want := "sh -c 'echo '\\''hi'\\''; python3 /app/main.py' < /app/.nebula/tests/3.in\n"
+	if !strings.HasPrefix(got, want) {
+		t.Fatalf("caseCommand = %q, want prefix %q", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"fmt"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/docker"
+	"github.com/JullMol/nebula/pkg/config"
+)
+
+const mb = 1024 * 1024
+
+func resolveLimits(req *pb.ResourceLimits, defaults, max config.ResourceLimits) (docker.Limits, error) {
+	l := defaults
+	if req != nil {
+		if req.MemoryMb > 0 {
+			l.MemoryMB = req.MemoryMb
+		}
+		if req.Cpus > 0 {
+			l.CPUs = req.Cpus
+		}
+		if req.PidsLimit > 0 {
+			l.PidsLimit = req.PidsLimit
+		}
+		if req.TmpfsMb > 0 {
+			l.TmpfsMB = req.TmpfsMb
+		}
+	}
+
+	if max.MemoryMB > 0 && l.MemoryMB > max.MemoryMB {
+		return docker.Limits{}, fmt.Errorf("memory_mb %d melebihi batas worker %d", l.MemoryMB, max.MemoryMB)
+	}
+	if max.CPUs > 0 && l.CPUs > max.CPUs {
+		return docker.Limits{}, fmt.Errorf("cpus %.2f melebihi batas worker %.2f", l.CPUs, max.CPUs)
+	}
+	if max.PidsLimit > 0 && l.PidsLimit > max.PidsLimit {
+		return docker.Limits{}, fmt.Errorf("pids_limit %d melebihi batas worker %d", l.PidsLimit, max.PidsLimit)
+	}
+	if max.TmpfsMB > 0 && l.TmpfsMB > max.TmpfsMB {
+		return docker.Limits{}, fmt.Errorf("tmpfs_mb %d melebihi batas worker %d", l.TmpfsMB, max.TmpfsMB)
+	}
+
+	return docker.Limits{
+		MemoryBytes: l.MemoryMB * mb,
+		NanoCPUs:    int64(l.CPUs * 1e9),
+		PidsLimit:   l.PidsLimit,
+		TmpfsBytes:  l.TmpfsMB * mb,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/archive"
+	"github.com/JullMol/nebula/internal/platform/runtimes"
+)
+
+func resolveCommand(req *pb.StartContainerRequest) (string, string, error) {
+	rt := req.Runtime
+	if rt == nil {
+		rt = &pb.Runtime{}
+	}
+	if req.Code != "" && len(req.Archive) > 0 {
+		return "", "", fmt.Errorf("code dan archive tidak bisa dipakai bersamaan")
+	}
+
+	fileName := rt.FileName
+	if len(req.Archive) > 0 && req.Entrypoint != "" {
+		entrypoint, err := archive.CleanPath(req.Entrypoint)
+		if err != nil {
+			return "", "", err
+		}
+		fileName = entrypoint
+	}
+	if (req.Code != "" || len(req.Archive) > 0) && fileName == "" {
+		return "", "", fmt.Errorf("runtime tanpa file_name, code tidak bisa ditulis")
+	}
+	if req.Code != "" && path.Base(fileName) != fileName {
+		return "", "", fmt.Errorf("nama file %q tidak valid", fileName)
+	}
+
+	if req.Command != "" {
+		return req.Command, fileName, nil
+	}
+	if rt.RunCommand == "" {
+		return "", "", fmt.Errorf("command atau runtime wajib diisi")
+	}
+	return runtimes.Expand(rt.RunCommand, fileName), fileName, nil
+}
+
+func (s *Server) packSources(req *pb.StartContainerRequest, fileName string, writable bool) ([]byte, error) {
+	if len(req.Archive) == 0 && req.Code == "" && req.Stdin == "" && len(req.Tests) == 0 {
+		return nil, nil
+	}
+
+	rootMode := int64(0755)
+	if writable {
+		rootMode = 0777
+	}
+	now := time.Now()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: rootMode, ModTime: now}); err != nil {
+		return nil, err
+	}
+
+	if len(req.Archive) > 0 {
+		if err := archive.Tar(req.Archive, s.archive, tw); err != nil {
+			return nil, err
+		}
+	} else if req.Code != "" {
+		err := tw.WriteHeader(&tar.Header{Name: fileName, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(req.Code)), ModTime: now})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(req.Code)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeInputs(tw, req, now); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *Server) Prepull(ctx context.Context, images []string) {
+	for _, img := range images {
+		started := time.Now()
+		if err := s.dockerClient.EnsureImage(ctx, img); err != nil {
+			fmt.Printf("⚠️ Gagal pre-pull image %s: %v\n", img, err)
+			continue
+		}
+		fmt.Printf("📦 Image %s siap (%s)\n", img, time.Since(started).Round(time.Second))
+	}
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/JullMol/nebula/internal/platform/docker"
+	"github.com/JullMol/nebula/pkg/config"
+)
+
+func loadSeccomp(cfg config.SandboxConfig) (string, error) {
+	if cfg.SeccompProfile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(cfg.SeccompProfile)
+	if err != nil {
+		return "", fmt.Errorf("gagal baca seccomp profile: %w", err)
+	}
+	return string(data), nil
+}
+
+func (s *Server) resolveSandbox(name string) (docker.SandboxProfile, error) {
+	if name == "" {
+		name = s.cfg.Sandbox.DefaultProfile
+	}
+	if name == "" {
+		name = docker.ProfileHardened
+	}
+
+	if name != s.cfg.Sandbox.DefaultProfile && !contains(s.cfg.Sandbox.AllowedProfiles, name) {
+		return docker.SandboxProfile{}, fmt.Errorf("sandbox profile %q tidak diizinkan di worker ini", name)
+	}
+
+	profile, ok := docker.Profiles[name]
+	if !ok {
+		return docker.SandboxProfile{}, fmt.Errorf("sandbox profile %q tidak dikenal", name)
+	}
+
+	if profile.Name == docker.ProfileHardened {
+		if s.cfg.Sandbox.User != "" {
+			profile.User = s.cfg.Sandbox.User
+		}
+		profile.SeccompProfile = s.seccomp
+	}
+	return profile, nil
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -2,35 +2,237 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	pb "github.com/JullMol/nebula/api/pb"
+	"github.com/JullMol/nebula/internal/platform/archive"
 	"github.com/JullMol/nebula/internal/platform/docker"
+	"github.com/JullMol/nebula/internal/platform/judge"
+	"github.com/JullMol/nebula/pkg/config"
+	"github.com/docker/docker/errdefs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const fallbackTimeout = 30 * time.Second
+
 type Server struct {
 	pb.UnimplementedWorkerServiceServer
-	dockerClient *docker.Client 
+	dockerClient *docker.Client
+	cfg          config.WorkerConfig
+	seccomp      string
+	archive      archive.Limits
+
+	mu       sync.Mutex
+	timedOut map[string]bool
+	reserved map[string]docker.Limits
+	running  atomic.Int32
 }
 
-func NewServer(dockerClient *docker.Client) *Server {
-	return &Server{dockerClient: dockerClient}
+func NewServer(dockerClient *docker.Client, cfg config.WorkerConfig) (*Server, error) {
+	if cfg.DefaultTimeout <= 0 {
+		cfg.DefaultTimeout = fallbackTimeout
+	}
+	if cfg.CompileLimits == (config.ResourceLimits{}) {
+		cfg.CompileLimits = cfg.DefaultLimits
+	}
+	if cfg.GC.Retention <= 0 {
+		cfg.GC.Retention = defaultRetention
+	}
+	if cfg.GC.SweepInterval <= 0 {
+		cfg.GC.SweepInterval = defaultSweepInterval
+	}
+	if cfg.GC.MaxAge <= 0 {
+		cfg.GC.MaxAge = defaultMaxAge
+	}
+	seccomp, err := loadSeccomp(cfg.Sandbox)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TotalMemoryMB <= 0 || cfg.TotalCPUs <= 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		memoryMB, cpus, err := dockerClient.HostResources(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("⚠️ Gagal baca resource host dari Docker: %v\n", err)
+		} else {
+			if cfg.TotalMemoryMB <= 0 {
+				cfg.TotalMemoryMB = memoryMB
+			}
+			if cfg.TotalCPUs <= 0 {
+				cfg.TotalCPUs = cpus
+			}
+		}
+	}
+	return &Server{
+		dockerClient: dockerClient,
+		cfg:          cfg,
+		seccomp:      seccomp,
+		archive:      archive.LimitsFrom(cfg.Archive),
+		timedOut:     make(map[string]bool),
+		reserved:     make(map[string]docker.Limits),
+	}, nil
 }
 
 func (s *Server) StartContainer(ctx context.Context, req *pb.StartContainerRequest) (*pb.StartContainerResponse, error) {
-	fmt.Printf("🚀 Request Masuk: Image=%s | CodeLength=%d\n", req.Image, len(req.Code))
+	fmt.Printf("🚀 Request Masuk: Image=%s | Runtime=%s %s | CodeLength=%d | Sandbox=%s\n", req.Image, req.Runtime.GetLanguage(), req.Runtime.GetVersion(), len(req.Code), req.SandboxProfile)
 
-	containerID, err := s.dockerClient.RunContainer(ctx, req.Image, req.Command, req.Code)
-	
+	command, fileName, err := resolveCommand(req)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limits, err := resolveLimits(req.Limits, s.cfg.DefaultLimits, s.cfg.MaxLimits)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sandbox, err := s.resolveSandbox(req.SandboxProfile)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	compile := req.Runtime.GetCompileCommand() != ""
+	files, err := s.packSources(req, fileName, compile)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	spec := docker.ContainerSpec{
+		JobID:   req.JobId,
+		Image:   req.Image,
+		Command: wrapCommand(req, command),
+		Files:   files,
+		Limits:  limits,
+		Sandbox: sandbox,
 	}
 
+	var compiled *pb.CompileResult
+	if compile {
+		vol, result, err := s.compile(ctx, req, fileName, files, sandbox)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Success {
+			return &pb.StartContainerResponse{Compile: result}, nil
+		}
+		spec.Files = nil
+		spec.Volume = vol
+		spec.ReadOnly = true
+		compiled = result
+	}
+
+	timeout := s.cfg.DefaultTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+
+	if len(req.Tests) > 0 {
+		outcomes, err := s.runTests(ctx, req, spec, command, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.StartContainerResponse{Compile: compiled, Tests: outcomes}, nil
+	}
+
+	containerID, err := s.dockerClient.RunContainer(ctx, spec)
+
+	if err != nil {
+		return nil, dockerStatus(err)
+	}
+
+	s.running.Add(1)
+	s.mu.Lock()
+	s.reserved[containerID] = limits
+	s.mu.Unlock()
+	go s.enforceTimeout(containerID, timeout)
+
 	return &pb.StartContainerResponse{
 		ContainerId: containerID,
+		Compile:     compiled,
 	}, nil
 }
 
+func (s *Server) enforceTimeout(containerID string, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	defer s.release(containerID)
+	defer s.scheduleRemoval(containerID)
+
+	err := s.dockerClient.WaitContainer(ctx, containerID)
+	if err == nil || ctx.Err() != context.DeadlineExceeded {
+		return
+	}
+
+	s.mu.Lock()
+	s.timedOut[containerID] = true
+	s.mu.Unlock()
+
+	fmt.Printf("⏰ Container %s melewati batas waktu %s, di-kill\n", containerID, timeout)
+	if err := s.dockerClient.KillContainer(context.Background(), containerID); err != nil {
+		fmt.Printf("⚠️ Gagal kill container %s: %v\n", containerID, err)
+	}
+}
+
+func (s *Server) release(containerID string) {
+	s.running.Add(-1)
+	s.mu.Lock()
+	delete(s.reserved, containerID)
+	s.mu.Unlock()
+}
+
+func (s *Server) MaxRecvMsgSize() int {
+	return int(s.archive.MaxArchiveBytes) + judge.MaxInputBytes + 4*mb
+}
+
+func (s *Server) Running() int {
+	return int(s.running.Load())
+}
+
+func (s *Server) Capacity() (int64, float64) {
+	return s.cfg.TotalMemoryMB, s.cfg.TotalCPUs
+}
+
+func (s *Server) CachedImages(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	images, err := s.dockerClient.ListImages(ctx)
+	if err != nil {
+		fmt.Printf("⚠️ Gagal list image lokal: %v\n", err)
+		return nil
+	}
+	return images
+}
+
+func (s *Server) Free() (int64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	memoryMB, cpus := s.cfg.TotalMemoryMB, s.cfg.TotalCPUs
+	for _, l := range s.reserved {
+		memoryMB -= l.MemoryBytes / mb
+		cpus -= float64(l.NanoCPUs) / 1e9
+	}
+	if memoryMB < 0 {
+		memoryMB = 0
+	}
+	if cpus < 0 {
+		cpus = 0
+	}
+	return memoryMB, cpus
+}
+
+func (s *Server) isTimedOut(containerID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timedOut[containerID]
+}
+
 func (s *Server) StopContainer(ctx context.Context, req *pb.StopContainerRequest) (*pb.StopContainerResponse, error) {
 	err := s.dockerClient.StopContainer(ctx, req.ContainerId)
 	if err != nil {
@@ -41,10 +243,61 @@ func (s *Server) StopContainer(ctx context.Context, req *pb.StopContainerRequest
 
 func (s *Server) WaitContainer(ctx context.Context, req *pb.WaitContainerRequest) (*pb.WaitContainerResponse, error) {
 	err := s.dockerClient.WaitContainer(ctx, req.ContainerId)
+	if errdefs.IsNotFound(err) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		return &pb.WaitContainerResponse{Success: false}, err
 	}
-	return &pb.WaitContainerResponse{Success: true}, nil
+	resp := &pb.WaitContainerResponse{
+		Success:  true,
+		TimedOut: s.isTimedOut(req.ContainerId),
+	}
+	if state, err := s.dockerClient.InspectState(ctx, req.ContainerId); err == nil {
+		resp.OomKilled = state.OOMKilled
+		resp.ExitCode = int32(state.ExitCode)
+
+		startedAt, errStart := time.Parse(time.RFC3339Nano, state.StartedAt)
+		finishedAt, errFinish := time.Parse(time.RFC3339Nano, state.FinishedAt)
+		if errStart == nil && errFinish == nil {
+			resp.StartedAt = timestamppb.New(startedAt)
+			resp.FinishedAt = timestamppb.New(finishedAt)
+			resp.DurationMs = finishedAt.Sub(startedAt).Milliseconds()
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) RemoveContainer(ctx context.Context, req *pb.RemoveContainerRequest) (*pb.RemoveContainerResponse, error) {
+	remove := func() error { return s.dockerClient.RemoveContainer(ctx, req.ContainerId, false) }
+	if req.Force {
+		remove = func() error { return s.dockerClient.RemoveJob(ctx, req.ContainerId) }
+	}
+	if err := remove(); err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return &pb.RemoveContainerResponse{Success: false}, err
+	}
+	return &pb.RemoveContainerResponse{Success: true}, nil
+}
+
+func (s *Server) ListContainers(ctx context.Context, req *pb.ListContainersRequest) (*pb.ListContainersResponse, error) {
+	containers, err := s.dockerClient.ListManaged(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListContainersResponse{}
+	for _, ctr := range containers {
+		resp.Containers = append(resp.Containers, &pb.ContainerInfo{
+			ContainerId: ctr.ID,
+			JobId:       ctr.JobID,
+			State:       ctr.State,
+			CreatedAt:   timestamppb.New(ctr.Created),
+		})
+	}
+	return resp, nil
 }
 
 func (s *Server) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLogsResponse, error) {
@@ -52,5 +305,34 @@ func (s *Server) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLo
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetLogsResponse{Logs: logs}, nil
-}
\ No newline at end of file
+	return &pb.GetLogsResponse{
+		Logs:   logs.Combined,
+		Stdout: logs.Stdout,
+		Stderr: logs.Stderr,
+	}, nil
+}
+
+func (s *Server) StreamLogs(req *pb.StreamLogsRequest, stream pb.WorkerService_StreamLogsServer) error {
+	return s.dockerClient.FollowLogs(stream.Context(), req.ContainerId, func(name string, data []byte) error {
+		return stream.Send(&pb.LogChunk{
+			Stream: name,
+			Data:   string(data),
+		})
+	})
+}
+
+func dockerStatus(err error) error {
+	switch {
+	case errdefs.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case errdefs.IsInvalidParameter(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errdefs.IsUnauthorized(err), errdefs.IsForbidden(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errdefs.IsDeadline(err):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled), errdefs.IsCancelled(err):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
@@ -1,23 +1,119 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server ServerConfig `mapstructure:"server"`
-	Worker WorkerConfig `mapstructure:"worker"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Orchestrator OrchestratorConfig `mapstructure:"orchestrator"`
+	Worker       WorkerConfig       `mapstructure:"worker"`
+	Runtimes     []RuntimeConfig    `mapstructure:"runtimes"`
+}
+
+type RuntimeConfig struct {
+	Language       string        `mapstructure:"language"`
+	Version        string        `mapstructure:"version"`
+	Image          string        `mapstructure:"image"`
+	FileName       string        `mapstructure:"file_name"`
+	CompileCommand string        `mapstructure:"compile_command"`
+	CompileTimeout time.Duration `mapstructure:"compile_timeout"`
+	RunCommand     string        `mapstructure:"run_command"`
+	Default        bool          `mapstructure:"default"`
+}
+
+type OrchestratorConfig struct {
+	MetricsPort         string        `mapstructure:"metrics_port"`
+	DispatchConcurrency int           `mapstructure:"dispatch_concurrency"`
+	LeaderLease         time.Duration `mapstructure:"leader_lease"`
+	ReconcileInterval   time.Duration `mapstructure:"reconcile_interval"`
+	ReconcileStaleAfter time.Duration `mapstructure:"reconcile_stale_after"`
 }
 
 type ServerConfig struct {
 	Port    string   `mapstructure:"port"`
 	Workers []string `mapstructure:"workers"`
 	RedisAddr string `mapstructure:"redis_addr"`
+
+	QueueVisibilityTimeout time.Duration   `mapstructure:"queue_visibility_timeout"`
+	Retry                  RetryConfig     `mapstructure:"retry"`
+	DefaultJobTimeout      time.Duration   `mapstructure:"default_job_timeout"`
+	MaxJobTimeout          time.Duration   `mapstructure:"max_job_timeout"`
+	ControlPlanePort       string          `mapstructure:"control_plane_port"`
+	OrchestratorAddr       string          `mapstructure:"orchestrator_addr"`
+	HeartbeatTTL           time.Duration   `mapstructure:"heartbeat_ttl"`
+	Health                 HealthConfig    `mapstructure:"health"`
+	Scheduler              SchedulerConfig `mapstructure:"scheduler"`
+	Archive                ArchiveConfig   `mapstructure:"archive"`
+}
+
+type ArchiveConfig struct {
+	MaxArchiveMB   int64 `mapstructure:"max_archive_mb"`
+	MaxExtractedMB int64 `mapstructure:"max_extracted_mb"`
+	MaxFiles       int   `mapstructure:"max_files"`
+}
+
+type SchedulerConfig struct {
+	Policy string `mapstructure:"policy"`
+}
+
+type HealthConfig struct {
+	CheckInterval    time.Duration `mapstructure:"check_interval"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	OpenDuration     time.Duration `mapstructure:"open_duration"`
+}
+
+type RetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	Multiplier     float64       `mapstructure:"multiplier"`
+	RetryableCodes []string      `mapstructure:"retryable_codes"`
 }
 
 type WorkerConfig struct {
 	Port string `mapstructure:"port"`
 	Name string `mapstructure:"name"`
+
+	AdvertiseAddr    string            `mapstructure:"advertise_addr"`
+	ControlPlaneAddr string            `mapstructure:"control_plane_addr"`
+	Capacity         int               `mapstructure:"capacity"`
+	Labels           map[string]string `mapstructure:"labels"`
+	Weight           int               `mapstructure:"weight"`
+	TotalMemoryMB    int64             `mapstructure:"total_memory_mb"`
+	TotalCPUs        float64           `mapstructure:"total_cpus"`
+
+	DefaultTimeout time.Duration  `mapstructure:"default_timeout"`
+	DefaultLimits  ResourceLimits `mapstructure:"default_limits"`
+	MaxLimits      ResourceLimits `mapstructure:"max_limits"`
+	CompileLimits  ResourceLimits `mapstructure:"compile_limits"`
+	PrepullImages  bool           `mapstructure:"prepull_images"`
+	Sandbox        SandboxConfig  `mapstructure:"sandbox"`
+	GC             GCConfig       `mapstructure:"gc"`
+	Archive        ArchiveConfig  `mapstructure:"archive"`
+}
+
+type GCConfig struct {
+	Retention     time.Duration `mapstructure:"retention"`
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+	MaxAge        time.Duration `mapstructure:"max_age"`
+}
+
+type SandboxConfig struct {
+	DefaultProfile  string   `mapstructure:"default_profile"`
+	AllowedProfiles []string `mapstructure:"allowed_profiles"`
+	User            string   `mapstructure:"user"`
+	SeccompProfile  string   `mapstructure:"seccomp_profile"`
+}
+
+type ResourceLimits struct {
+	MemoryMB  int64   `mapstructure:"memory_mb"`
+	CPUs      float64 `mapstructure:"cpus"`
+	PidsLimit int64   `mapstructure:"pids_limit"`
+	TmpfsMB   int64   `mapstructure:"tmpfs_mb"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -25,6 +121,7 @@ func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err != nil {